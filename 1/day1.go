@@ -0,0 +1,115 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/dinord/aoc23/parse"
+	"github.com/dinord/aoc23/solver"
+)
+
+// Part one: only numerals count as digits.
+var numeralToValue = map[string]int{
+	"0": 0,
+	"1": 1,
+	"2": 2,
+	"3": 3,
+	"4": 4,
+	"5": 5,
+	"6": 6,
+	"7": 7,
+	"8": 8,
+	"9": 9,
+}
+
+// Part two: digits may also be spelled out.
+var digitToValue = map[string]int{
+	"zero":  0,
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+	"seven": 7,
+	"eight": 8,
+	"nine":  9,
+	"0":     0,
+	"1":     1,
+	"2":     2,
+	"3":     3,
+	"4":     4,
+	"5":     5,
+	"6":     6,
+	"7":     7,
+	"8":     8,
+	"9":     9,
+}
+
+func findFirstDigit(line string, reverseKey bool, digits map[string]int) (value int, index int) {
+	var firstValue int = -1
+	firstDigitIndex := math.MaxInt
+	for d, v := range digits {
+		digitBytes := []byte(strings.Clone(d))
+		if reverseKey {
+			slices.Reverse(digitBytes)
+		}
+		digitIndex := strings.Index(line, string(digitBytes))
+		if digitIndex != -1 && digitIndex < firstDigitIndex {
+			firstValue = v
+			firstDigitIndex = digitIndex
+		}
+	}
+	return firstValue, firstDigitIndex
+}
+
+func extractLineValue(line []byte, digits map[string]int) (int, error) {
+	firstDigit, _ := findFirstDigit(string(line), false, digits)
+	if firstDigit == -1 {
+		return 0, fmt.Errorf("Expecting at least one digit per line, found none in: %s", line)
+	}
+	slices.Reverse(line)
+	lastDigit, _ := findFirstDigit(string(line), true, digits)
+	return (firstDigit*10 + lastDigit), nil
+}
+
+func computeCalibrationValue(input io.Reader, digits map[string]int) (int, error) {
+	scanner := parse.NewScanner(input)
+	var calibrationValue int = 0
+	for scanner.Scan() {
+		value, err := extractLineValue([]byte(scanner.Text()), digits)
+		if err != nil {
+			return 0, scanner.Wrap(err)
+		}
+		calibrationValue += value
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return calibrationValue, nil
+}
+
+type solution struct{}
+
+func init() {
+	solver.Register(solver.Day{Number: 1, Solver: solution{}})
+}
+
+func (solution) Part1(input io.Reader) (solver.Answer, error) {
+	return solve(input, numeralToValue)
+}
+
+func (solution) Part2(input io.Reader) (solver.Answer, error) {
+	return solve(input, digitToValue)
+}
+
+func solve(input io.Reader, digits map[string]int) (solver.Answer, error) {
+	value, err := computeCalibrationValue(input, digits)
+	if err != nil {
+		return "", err
+	}
+	return solver.Int(value), nil
+}
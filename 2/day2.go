@@ -0,0 +1,157 @@
+package day2
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dinord/aoc23/parse"
+	"github.com/dinord/aoc23/solver"
+)
+
+type cubeSet struct {
+	red   int
+	green int
+	blue  int
+}
+
+func parseCubeSet(f parse.Field) (set cubeSet, err error) {
+	for _, part := range f.Split(",") {
+		fields := part.Fields()
+		if len(fields) != 2 {
+			return cubeSet{}, part.TrimSpace().Errorf("Expected `<count> <color>`, got: %s", part.Text)
+		}
+		value, err := fields[0].Int()
+		if err != nil {
+			return cubeSet{}, err
+		}
+		switch color := fields[1]; color.Text {
+		case "red":
+			set.red = value
+		case "green":
+			set.green = value
+		case "blue":
+			set.blue = value
+		default:
+			return cubeSet{}, color.Errorf("Failed to parse unknown color (not RGB): %s", color.Text)
+		}
+	}
+	return set, nil
+}
+
+func parseGame(line parse.Field) (id int, sets []cubeSet, err error) {
+	key, value, err := line.KeyValue()
+	if err != nil {
+		return -1, []cubeSet{}, err
+	}
+	keyFields := key.Fields()
+	if len(keyFields) != 2 || keyFields[0].Text != "Game" {
+		return -1, []cubeSet{}, key.Errorf("Expected `Game <id>: ...`, got: %s", line.Text)
+	}
+	id, err = keyFields[1].Int()
+	if err != nil {
+		return -1, []cubeSet{}, err
+	}
+
+	parts := value.Split(";")
+	sets = make([]cubeSet, len(parts))
+	for i, part := range parts {
+		sets[i], err = parseCubeSet(part)
+		if err != nil {
+			return -1, []cubeSet{}, err
+		}
+	}
+	return id, sets, nil
+}
+
+func minFeasibleSet(sets []cubeSet) (min cubeSet, err error) {
+	if len(sets) == 0 {
+		return cubeSet{}, errors.New("minFeasibleSet: Expected at least one cubeSet!")
+	}
+	min = sets[0]
+	for _, set := range sets {
+		if set.red > min.red {
+			min.red = set.red
+		}
+		if set.green > min.green {
+			min.green = set.green
+		}
+		if set.blue > min.blue {
+			min.blue = set.blue
+		}
+	}
+	return min, nil
+}
+
+// Whether every set in a game could have been drawn from a bag
+// holding `limits` cubes.
+func isFeasible(sets []cubeSet, limits cubeSet) bool {
+	for _, set := range sets {
+		if set.red > limits.red || set.green > limits.green || set.blue > limits.blue {
+			return false
+		}
+	}
+	return true
+}
+
+// Calls `visit` with every game in the input, stopping at the first error.
+func scanGames(input io.Reader, visit func(id int, sets []cubeSet) error) error {
+	scanner := parse.NewScanner(input)
+	for scanner.Scan() {
+		id, cubeSets, err := parseGame(scanner.Field())
+		if err != nil {
+			return fmt.Errorf("Failed to parse game: %w", scanner.Wrap(err))
+		}
+		if err := visit(id, cubeSets); err != nil {
+			return scanner.Wrap(err)
+		}
+	}
+	return scanner.Err()
+}
+
+func computeFeasibleIdSum(input io.Reader, limits cubeSet) (int, error) {
+	idSum := 0
+	err := scanGames(input, func(id int, cubeSets []cubeSet) error {
+		if isFeasible(cubeSets, limits) {
+			idSum += id
+		}
+		return nil
+	})
+	return idSum, err
+}
+
+func computePowerSum(input io.Reader) (int, error) {
+	powerSum := 0
+	err := scanGames(input, func(_ int, cubeSets []cubeSet) error {
+		min, err := minFeasibleSet(cubeSets)
+		if err != nil {
+			return err
+		}
+		powerSum += (min.red * min.green * min.blue)
+		return nil
+	})
+	return powerSum, err
+}
+
+type solution struct{}
+
+func init() {
+	solver.Register(solver.Day{Number: 2, Solver: solution{}})
+}
+
+func (solution) Part1(input io.Reader) (solver.Answer, error) {
+	cubeLimits := cubeSet{red: 12, green: 13, blue: 14}
+	sum, err := computeFeasibleIdSum(input, cubeLimits)
+	if err != nil {
+		return "", err
+	}
+	return solver.Int(sum), nil
+}
+
+func (solution) Part2(input io.Reader) (solver.Answer, error) {
+	sum, err := computePowerSum(input)
+	if err != nil {
+		return "", err
+	}
+	return solver.Int(sum), nil
+}
@@ -0,0 +1,183 @@
+package day3
+
+import (
+	"io"
+	"strconv"
+	"unicode"
+
+	"github.com/dinord/aoc23/interval"
+	"github.com/dinord/aoc23/parse"
+	"github.com/dinord/aoc23/solver"
+)
+
+type iposition struct {
+	row int
+	col int
+}
+
+func findDigitRanges(s string) []interval.Range {
+	ranges := make([]interval.Range, 0, 10) // Arbitrary initial capacity.
+	inRange := false
+	for i, r := range s {
+		isDigit := unicode.IsDigit(r)
+		if isDigit && !inRange {
+			inRange = true
+			ranges = append(ranges, interval.Range{Start: i})
+		} else if !isDigit && inRange {
+			inRange = false
+			ranges[len(ranges)-1].End = i
+		}
+	}
+	if inRange {
+		ranges[len(ranges)-1].End = len(s)
+	}
+	return ranges
+}
+
+func isGear(b byte) bool {
+	return b == '*'
+}
+
+func isSymbol(b byte) bool {
+	return b != '.' && !('0' <= b && b <= '9')
+}
+
+// Finds the positions next to the digits in `r`, relative to `line`,
+// whose bytes match `pred`.
+func adjacentSymbols(r interval.Range, prevLine, line, nextLine string, pred func(byte) bool) []iposition {
+	// Initialize to arbitrary small capacity.
+	symbols := make([]iposition, 0, 10)
+	if r.Start > 0 && pred(line[r.Start-1]) {
+		symbols = append(symbols, iposition{row: 0, col: r.Start - 1})
+	}
+	if r.End < len(line) && pred(line[r.End]) {
+		symbols = append(symbols, iposition{row: 0, col: r.End})
+	}
+
+	end := min(r.End+1, len(line))
+	for i := max(0, r.Start-1); i < end; i++ {
+		if pred(prevLine[i]) {
+			symbols = append(symbols, iposition{row: -1, col: i})
+		}
+		if pred(nextLine[i]) {
+			symbols = append(symbols, iposition{row: 1, col: i})
+		}
+	}
+	return symbols
+}
+
+// Sums the numbers in `line` that are next to a symbol.
+func sumPartNumbers(prev, line, next string) (sum int, err error) {
+	for _, r := range findDigitRanges(line) {
+		if len(adjacentSymbols(r, prev, line, next, isSymbol)) == 0 {
+			continue
+		}
+		num, err := strconv.Atoi(line[r.Start:r.End])
+		if err != nil {
+			return 0, err
+		}
+		sum += num
+	}
+	return sum, nil
+}
+
+type gearMap map[iposition][]int
+
+func (gears gearMap) updateParts(index int, prev, line, next string) error {
+	digitRanges := findDigitRanges(line)
+	for _, r := range digitRanges {
+		pos := adjacentSymbols(r, prev, line, next, isGear)
+		if len(pos) == 0 {
+			continue
+		}
+		num, err := strconv.Atoi(line[r.Start:r.End])
+		if err != nil {
+			return err
+		}
+
+		for _, p := range pos {
+			absp := iposition{row: index + p.row, col: p.col}
+			gears[absp] = append(gears[absp], num)
+		}
+	}
+	return nil
+}
+
+func makeString(b byte, l int) string {
+	bytes := make([]byte, l)
+	for i := 0; i < l; i++ {
+		bytes[i] = b
+	}
+	return string(bytes)
+}
+
+// Calls `visit` with every line of the schematic, along with its index
+// and the lines around it. Lines outside the schematic are all dots.
+func scanSchematic(input io.Reader, visit func(index int, prev, line, next string) error) error {
+	grid, err := parse.ReadGrid(input)
+	if err != nil {
+		return err
+	}
+
+	outside := makeString('.', grid.Width())
+	row := func(i int) string {
+		if i < 0 || i >= grid.Height() {
+			return outside
+		}
+		return grid.Rows[i]
+	}
+	for i := range grid.Rows {
+		if err := visit(i, row(i-1), row(i), row(i+1)); err != nil {
+			return parse.AtLine(i+1, err)
+		}
+	}
+	return nil
+}
+
+func computePartNumberSum(input io.Reader) (int, error) {
+	sum := 0
+	err := scanSchematic(input, func(_ int, prev, line, next string) error {
+		lineSum, err := sumPartNumbers(prev, line, next)
+		sum += lineSum
+		return err
+	})
+	return sum, err
+}
+
+func computeGearRatioSum(input io.Reader) (int, error) {
+	gears := make(gearMap)
+	err := scanSchematic(input, gears.updateParts)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0
+	for _, parts := range gears {
+		if len(parts) == 2 {
+			sum += parts[0] * parts[1]
+		}
+	}
+	return sum, nil
+}
+
+type solution struct{}
+
+func init() {
+	solver.Register(solver.Day{Number: 3, Solver: solution{}})
+}
+
+func (solution) Part1(input io.Reader) (solver.Answer, error) {
+	sum, err := computePartNumberSum(input)
+	if err != nil {
+		return "", err
+	}
+	return solver.Int(sum), nil
+}
+
+func (solution) Part2(input io.Reader) (solver.Answer, error) {
+	sum, err := computeGearRatioSum(input)
+	if err != nil {
+		return "", err
+	}
+	return solver.Int(sum), nil
+}
@@ -0,0 +1,36 @@
+package day3
+
+import (
+	"strings"
+	"testing"
+)
+
+var exampleSchematic = []string{
+	"467..114..",
+	"...*......",
+	"..35..633.",
+	"......#...",
+	"617*......",
+	".....+.58.",
+	"..592.....",
+	"......755.",
+	"...$.*....",
+	".664.598..",
+}
+
+func TestWideSchematic(t *testing.T) {
+	// Rows longer than any default line buffer.
+	padding := strings.Repeat(".", 1<<17)
+	rows := make([]string, len(exampleSchematic))
+	for i, row := range exampleSchematic {
+		rows[i] = padding + row + padding
+	}
+	input := strings.Join(rows, "\n")
+
+	if got, err := computePartNumberSum(strings.NewReader(input)); err != nil || got != 4361 {
+		t.Errorf("computePartNumberSum() = %d, %v, want 4361", got, err)
+	}
+	if got, err := computeGearRatioSum(strings.NewReader(input)); err != nil || got != 467835 {
+		t.Errorf("computeGearRatioSum() = %d, %v, want 467835", got, err)
+	}
+}
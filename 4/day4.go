@@ -0,0 +1,127 @@
+package day4
+
+import (
+	"io"
+
+	"github.com/dinord/aoc23/parse"
+	"github.com/dinord/aoc23/solver"
+)
+
+// Parses a card like `Card 1: 41 48 83 | 83 86  6 31`.
+func parseScratchCard(line parse.Field) (wins []int, scratches []int, err error) {
+	_, card, err := line.KeyValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	winsText, scratchesText, found := card.Cut("|")
+	if !found {
+		return nil, nil, card.Errorf("Expected card with `|`, got: %s", card.Text)
+	}
+
+	wins, err = winsText.Ints()
+	if err != nil {
+		return nil, nil, err
+	}
+	scratches, err = scratchesText.Ints()
+	if err != nil {
+		return nil, nil, err
+	}
+	return wins, scratches, nil
+}
+
+func scratchMatches(wins []int, scratches []int) int {
+	matches := 0
+	winSet := make(map[int]struct{})
+	for _, w := range wins {
+		winSet[w] = struct{}{}
+	}
+
+	for _, s := range scratches {
+		if _, match := winSet[s]; match {
+			matches++
+		}
+	}
+	return matches
+}
+
+func loadScratchMatches(input io.Reader) (matches []int, err error) {
+	scanner := parse.NewScanner(input)
+	matches = make([]int, 0, 10) // arbitrary capacity
+	for scanner.Scan() {
+		wins, scratches, err := parseScratchCard(scanner.Field())
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+		matches = append(matches, scratchMatches(wins, scratches))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+func computeScratchCardPoints(input io.Reader) (points int, err error) {
+	matches, err := loadScratchMatches(input)
+	if err != nil {
+		return -1, err
+	}
+
+	// The first match is worth one point, and every other match
+	// doubles the points of the card.
+	for _, m := range matches {
+		if m > 0 {
+			points += 1 << (m - 1)
+		}
+	}
+	return points, nil
+}
+
+func computeScratchCardCount(input io.Reader) (count int, err error) {
+	matches, err := loadScratchMatches(input)
+	if err != nil {
+		return -1, err
+	}
+
+	// This can be nlogn with heap.
+	// Probably linear with monotonic queue.
+	// Ain't got time for that, I have work tomorrow.
+	mlen := len(matches)
+	multipliers := make([]int, mlen)
+	for i, _ := range multipliers {
+		multipliers[i] = 1
+	}
+
+	for i, m := range matches {
+		for j := i; j < i+m && j < mlen-1; j++ {
+			multipliers[j+1] += multipliers[i]
+		}
+	}
+
+	count = 0
+	for _, m := range multipliers {
+		count += m
+	}
+	return count, nil
+}
+
+type solution struct{}
+
+func init() {
+	solver.Register(solver.Day{Number: 4, Solver: solution{}})
+}
+
+func (solution) Part1(input io.Reader) (solver.Answer, error) {
+	points, err := computeScratchCardPoints(input)
+	if err != nil {
+		return "", err
+	}
+	return solver.Int(points), nil
+}
+
+func (solution) Part2(input io.Reader) (solver.Answer, error) {
+	count, err := computeScratchCardCount(input)
+	if err != nil {
+		return "", err
+	}
+	return solver.Int(count), nil
+}
@@ -0,0 +1,633 @@
+package day5
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dinord/aoc23/interval"
+	"github.com/dinord/aoc23/parse"
+	"github.com/dinord/aoc23/solver"
+)
+
+type rangeMap struct {
+	src  interval.Range
+	dst  interval.Range
+	line int // in the input, starting from 1
+}
+
+type categoryMap struct {
+	src       string
+	dst       string
+	rangeMaps []rangeMap
+	line      int // of the header, starting from 1
+}
+
+// A chain of category maps, where each map's dst is the next map's src.
+type categoryPath []categoryMap
+
+type puzzle struct {
+	seeds     []interval.Range
+	seedsLine int // in the input, starting from 1
+	// Keyed by source category, in input order.
+	srcMaps map[string][]categoryMap
+}
+
+// Computes the end of a range, failing instead of silently wrapping around.
+// All arithmetic on ranges stays within [0, math.MaxInt] as long as every
+// range is built by this function and has a non-negative length.
+func rangeEnd(start int, length int) (int, error) {
+	if start < 0 {
+		return 0, fmt.Errorf("Expected non-negative range start, got: %d", start)
+	}
+	if length > 0 && start > math.MaxInt-length {
+		return 0, fmt.Errorf("Range with start %d and length %d overflows %d-bit integers", start, length, strconv.IntSize)
+	}
+	return start + length, nil
+}
+
+// Parses the seeds line. Part one lists individual seeds, part two
+// lists start-length pairs of seed ranges.
+func parseSeeds(line parse.Field, asRanges bool) (seeds []interval.Range, err error) {
+	value, err := line.Value("seeds")
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := value.Ints()
+	if err != nil {
+		return nil, err
+	}
+
+	if !asRanges {
+		seeds = make([]interval.Range, len(numbers))
+		for i, n := range numbers {
+			seeds[i].Start = n
+			seeds[i].End, err = rangeEnd(n, 1)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return seeds, nil
+	}
+
+	count := len(numbers)
+	if count%2 != 0 {
+		return nil, value.Errorf("Expected start-length pairs of seed locations, got: %s", value.Text)
+	}
+
+	seeds = make([]interval.Range, count/2)
+	for i, _ := range seeds {
+		seeds[i].Start = numbers[2*i]
+		seeds[i].End, err = rangeEnd(seeds[i].Start, numbers[2*i+1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return seeds, nil
+}
+
+// Parses a header like `seed-to-soil map:`.
+func parseSrcDst(line parse.Field) (src string, dst string, err error) {
+	key, value, err := line.KeyValue()
+	if err != nil {
+		return "", "", err
+	}
+	if value.Text != "" {
+		return "", "", value.Errorf("Unexpected text after the map name: %s", value.Text)
+	}
+	mapName, found := strings.CutSuffix(key.Text, " map")
+	if !found {
+		return "", "", key.Errorf("Expected `<key>-to-<value> map:`, got: %s", line.Text)
+	}
+
+	srcDst := strings.Split(mapName, "-to-")
+	if len(srcDst) != 2 {
+		return "", "", key.Errorf("Expected `key-to-value`, got: %s", line.Text)
+	}
+	return srcDst[0], srcDst[1], nil
+}
+
+func parseRangeMap(line parse.Field) (r rangeMap, err error) {
+	ints, err := line.Ints()
+	if err != nil {
+		return r, err
+	}
+	if len(ints) != 3 {
+		return r, line.Errorf("Expected `<d> <s> <l>`. Got: %s", line.Text)
+	}
+	length := ints[2]
+	r.src.Start = ints[1]
+	if r.src.End, err = rangeEnd(r.src.Start, length); err != nil {
+		return r, err
+	}
+	r.dst.Start = ints[0]
+	if r.dst.End, err = rangeEnd(r.dst.Start, length); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Parses a category map's block: the header, then one range map per line.
+func parseCategoryMap(b parse.Block) (cm categoryMap, err error) {
+	cm.src, cm.dst, err = parseSrcDst(b.Field(0))
+	if err != nil {
+		return cm, b.Wrap(0, err)
+	}
+	cm.line = b.Line
+	cm.rangeMaps = make([]rangeMap, 0, len(b.Lines)-1)
+	for i := 1; i < len(b.Lines); i++ {
+		r, err := parseRangeMap(b.Field(i))
+		if err != nil {
+			return cm, b.Wrap(i, err)
+		}
+		r.line = b.Line + i
+		cm.rangeMaps = append(cm.rangeMaps, r)
+	}
+	return cm, nil
+}
+
+func loadPuzzle(input io.Reader, seedRanges bool) (p puzzle, err error) {
+	blocks, err := parse.Blocks(input)
+	if err != nil {
+		return p, err
+	}
+	if len(blocks) == 0 {
+		return p, fmt.Errorf("Expected line with seeds!")
+	}
+	seedBlock := blocks[0]
+	p.seeds, err = parseSeeds(seedBlock.Field(0), seedRanges)
+	if err != nil {
+		return p, seedBlock.Wrap(0, err)
+	}
+	p.seedsLine = seedBlock.Line
+	if len(seedBlock.Lines) > 1 {
+		return p, seedBlock.Wrap(1, errors.New("Expected a blank line after the seeds"))
+	}
+
+	p.srcMaps = make(map[string][]categoryMap)
+	for _, b := range blocks[1:] {
+		cm, err := parseCategoryMap(b)
+		if err != nil {
+			return p, err
+		}
+		p.srcMaps[cm.src] = append(p.srcMaps[cm.src], cm)
+	}
+	return p, nil
+}
+
+type almanacIssueKind int
+
+const (
+	emptyRange almanacIssueKind = iota
+	overlappingRanges
+	duplicateCategories
+	// Values in gaps map to themselves, so gaps are legal, but worth a look.
+	rangeGap
+)
+
+type almanacIssue struct {
+	kind    almanacIssueKind
+	line    int
+	message string
+}
+
+func (issue almanacIssue) String() string {
+	return fmt.Sprintf("Line %d: %s", issue.line, issue.message)
+}
+
+// Checks the range maps of a single category map for empty ranges,
+// overlapping source ranges and gaps between source ranges.
+func validateCategoryMap(cm categoryMap) []almanacIssue {
+	issues := make([]almanacIssue, 0)
+	name := fmt.Sprintf("%s-to-%s", cm.src, cm.dst)
+	sorted := make([]rangeMap, 0, len(cm.rangeMaps))
+	for _, m := range cm.rangeMaps {
+		if m.src.End <= m.src.Start {
+			issues = append(issues, almanacIssue{
+				kind:    emptyRange,
+				line:    m.line,
+				message: fmt.Sprintf("Range of length %d in %s map", m.src.End-m.src.Start, name),
+			})
+			continue
+		}
+		sorted = append(sorted, m)
+	}
+	if len(sorted) == 0 {
+		return issues
+	}
+
+	slices.SortStableFunc(sorted, func(a, b rangeMap) int { return cmp.Compare(a.src.Start, b.src.Start) })
+	// The range reaching furthest right among those already visited.
+	furthest := sorted[0]
+	for _, m := range sorted[1:] {
+		if m.src.Start < furthest.src.End {
+			issues = append(issues, almanacIssue{
+				kind: overlappingRanges,
+				line: m.line,
+				message: fmt.Sprintf("Source range [%d, %d) overlaps [%d, %d) from line %d in %s map",
+					m.src.Start, m.src.End, furthest.src.Start, furthest.src.End, furthest.line, name),
+			})
+		} else if m.src.Start > furthest.src.End {
+			issues = append(issues, almanacIssue{
+				kind: rangeGap,
+				line: m.line,
+				message: fmt.Sprintf("Gap [%d, %d) between source ranges from lines %d and %d in %s map",
+					furthest.src.End, m.src.Start, furthest.line, m.line, name),
+			})
+		}
+		if m.src.End > furthest.src.End {
+			furthest = m
+		}
+	}
+	return issues
+}
+
+// Reports all issues in the puzzle, ordered by line.
+func validatePuzzle(p puzzle) []almanacIssue {
+	issues := make([]almanacIssue, 0)
+	for i, seed := range p.seeds {
+		if seed.End <= seed.Start {
+			issues = append(issues, almanacIssue{
+				kind:    emptyRange,
+				line:    p.seedsLine,
+				message: fmt.Sprintf("Seed range #%d has length %d", i+1, seed.End-seed.Start),
+			})
+		}
+	}
+
+	for _, cms := range p.srcMaps {
+		// Maps with the same source and target category, by target.
+		seen := make(map[string]categoryMap)
+		for _, cm := range cms {
+			if first, ok := seen[cm.dst]; ok {
+				issues = append(issues, almanacIssue{
+					kind:    duplicateCategories,
+					line:    cm.line,
+					message: fmt.Sprintf("Duplicate %s-to-%s map, first defined on line %d", cm.src, cm.dst, first.line),
+				})
+			} else {
+				seen[cm.dst] = cm
+			}
+			issues = append(issues, validateCategoryMap(cm)...)
+		}
+	}
+
+	slices.SortStableFunc(issues, func(a, b almanacIssue) int { return cmp.Compare(a.line, b.line) })
+	return issues
+}
+
+// Splits `r` into the part moved by `m`, already moved to `m.dst`,
+// and the rest that `m` does not cover.
+func (m rangeMap) apply(r interval.Set) (unmapped interval.Set, mapped interval.Set) {
+	src := interval.NewSet(m.src)
+	return r.Difference(src), r.Intersect(src).Shift(m.dst.Start - m.src.Start)
+}
+
+// A piece of an input range, and where a category map sends it.
+type tracePiece struct {
+	Src interval.Range `json:"src"`
+	Dst interval.Range `json:"dst"`
+	// Line of the range map that moved the piece, zero if none did.
+	MapLine int `json:"map_line,omitempty"`
+}
+
+// Like applyAll, but also reports which range map each piece hit.
+func traceAll(r interval.Range, maps []rangeMap) []tracePiece {
+	original := interval.NewSet(r)
+	pieces := make([]tracePiece, 0, 10) // arbitrary capacity
+	for _, m := range maps {
+		offset := m.dst.Start - m.src.Start
+		unmapped, mapped := m.apply(original)
+		for dst := range mapped.All() {
+			pieces = append(pieces, tracePiece{Src: dst.Shift(-offset), Dst: dst, MapLine: m.line})
+		}
+		original = unmapped
+	}
+
+	for r := range original.All() {
+		pieces = append(pieces, tracePiece{Src: r, Dst: r})
+	}
+	return pieces
+}
+
+func applyAll(r interval.Range, maps []rangeMap) []interval.Range {
+	pieces := traceAll(r, maps)
+	applied := make([]interval.Range, len(pieces))
+	for i, p := range pieces {
+		applied[i] = p.Dst
+	}
+	return applied
+}
+
+func applyToAll(rs []interval.Range, maps []rangeMap) []interval.Range {
+	applied := make([]interval.Range, 0, len(rs))
+	for _, r := range rs {
+		applied = append(applied, applyAll(r, maps)...)
+	}
+	return applied
+}
+
+func (path categoryPath) String() string {
+	if len(path) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(path)+1)
+	names = append(names, path[0].src)
+	for _, cm := range path {
+		names = append(names, cm.dst)
+	}
+	return strings.Join(names, " -> ")
+}
+
+// Finds all chains of category maps leading from `from` to `to`,
+// in the order the maps appear in the input.
+// Fails if the maps reachable from `from` form a cycle.
+func findCategoryPaths(srcMaps map[string][]categoryMap, from string, to string) (paths []categoryPath, err error) {
+	if from == to {
+		return nil, fmt.Errorf("Expected different source and target categories, got: %s", from)
+	}
+
+	paths = make([]categoryPath, 0, 1)
+	current := make(categoryPath, 0, len(srcMaps))
+	onPath := map[string]bool{from: true}
+	var walk func(key string) error
+	walk = func(key string) error {
+		for _, cm := range srcMaps[key] {
+			if onPath[cm.dst] {
+				start := slices.IndexFunc(current, func(c categoryMap) bool { return c.src == cm.dst })
+				if start == -1 {
+					// A map like `soil-to-soil` is a cycle on its own.
+					start = len(current)
+				}
+				cycle := append(slices.Clone(current[start:]), cm)
+				return fmt.Errorf("Category maps form a cycle: %v", cycle)
+			}
+			current = append(current, cm)
+			if cm.dst == to {
+				paths = append(paths, slices.Clone(current))
+			} else {
+				onPath[cm.dst] = true
+				if err := walk(cm.dst); err != nil {
+					return err
+				}
+				delete(onPath, cm.dst)
+			}
+			current = current[:len(current)-1]
+		}
+		return nil
+	}
+	if err := walk(from); err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("Category `%s` is unreachable from `%s`", to, from)
+	}
+	return paths, nil
+}
+
+type traceStep struct {
+	Src     string           `json:"src"`
+	Dst     string           `json:"dst"`
+	MapLine int              `json:"map_line"`
+	Input   []interval.Range `json:"input"`
+	Pieces  []tracePiece     `json:"pieces"`
+	Output  []interval.Range `json:"output"`
+}
+
+// Same as findSeedLocations, but records how the ranges split at every step.
+func traceSeedLocations(seeds []interval.Range, path categoryPath) []traceStep {
+	steps := make([]traceStep, 0, len(path))
+	seedValues := seeds
+	for _, cm := range path {
+		step := traceStep{Src: cm.src, Dst: cm.dst, MapLine: cm.line, Input: seedValues}
+		step.Pieces = make([]tracePiece, 0, len(seedValues))
+		for _, r := range seedValues {
+			step.Pieces = append(step.Pieces, traceAll(r, cm.rangeMaps)...)
+		}
+		step.Output = make([]interval.Range, len(step.Pieces))
+		for i, p := range step.Pieces {
+			step.Output[i] = p.Dst
+		}
+		steps = append(steps, step)
+		seedValues = step.Output
+	}
+	return steps
+}
+
+func joinRanges(rs []interval.Range) string {
+	parts := make([]string, len(rs))
+	for i, r := range rs {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+func writeTraceText(w io.Writer, steps []traceStep) {
+	for _, step := range steps {
+		fmt.Fprintf(w, "%s -> %s (line %d)\n", step.Src, step.Dst, step.MapLine)
+		fmt.Fprintf(w, "  in:  %s\n", joinRanges(step.Input))
+		for _, p := range step.Pieces {
+			if p.MapLine == 0 {
+				fmt.Fprintf(w, "    %v -> %v unmapped\n", p.Src, p.Dst)
+			} else {
+				fmt.Fprintf(w, "    %v -> %v by line %d\n", p.Src, p.Dst, p.MapLine)
+			}
+		}
+		fmt.Fprintf(w, "  out: %s\n", joinRanges(step.Output))
+	}
+}
+
+func findSeedLocations(seeds []interval.Range, path categoryPath) []interval.Range {
+	seedValues := seeds
+	for _, cm := range path {
+		seedValues = applyToAll(seedValues, cm.rangeMaps)
+	}
+	return seedValues
+}
+
+// Every seed maps to exactly one location, so mapping ranges keeps
+// their total length. The sum may wrap around, which is fine for comparing.
+func totalLen(rs []interval.Range) (total int) {
+	for _, r := range rs {
+		total += r.Len()
+	}
+	return total
+}
+
+func computeLowestSeedLocation(seeds []interval.Range, path categoryPath) (location int, err error) {
+	if len(seeds) == 0 {
+		return -1, fmt.Errorf("Expected at least one seed in the puzzle, got none!")
+	}
+
+	// Run a simple algorithm on read data without any preprocessing.
+	// Do not sort range maps and use binary search, not worth it.
+	locations := findSeedLocations(seeds, path)
+	if n, m := totalLen(seeds), totalLen(locations); n != m {
+		return -1, solver.Errorf(solver.InternalError, "Mapped %d seeds to %d locations along %v", n, m, path)
+	}
+	if len(locations) == 0 {
+		return -1, fmt.Errorf("Expected at least one seed in the puzzle, got only empty seed ranges")
+	}
+	minLocation := math.MaxInt
+	for _, loc := range locations {
+		if loc.Start < minLocation {
+			minLocation = loc.Start
+		}
+	}
+	return minLocation, nil
+}
+
+// Picks the path at `index`, or the only path if `index` is negative.
+func choosePath(paths []categoryPath, index int) (categoryPath, error) {
+	if index >= len(paths) {
+		return nil, fmt.Errorf("Path index %d out of range, found %d paths", index, len(paths))
+	}
+	if index >= 0 {
+		return paths[index], nil
+	}
+	if len(paths) > 1 {
+		var b strings.Builder
+		for i, path := range paths {
+			fmt.Fprintf(&b, "\n  %d: %v", i, path)
+		}
+		return nil, fmt.Errorf("Found %d paths, choose one with `aoc day 5 --path <index>`:%s", len(paths), b.String())
+	}
+	return paths[0], nil
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 5, Solver: solution{}, Command: Command})
+}
+
+type solution struct{}
+
+func (solution) Part1(input io.Reader) (solver.Answer, error) {
+	return solve(input, false)
+}
+
+func (solution) Part2(input io.Reader) (solver.Answer, error) {
+	return solve(input, true)
+}
+
+func solve(input io.Reader, seedRanges bool) (solver.Answer, error) {
+	puzzle, err := loadPuzzle(input, seedRanges)
+	if err != nil {
+		return "", err
+	}
+	paths, err := findCategoryPaths(puzzle.srcMaps, "seed", "location")
+	if err != nil {
+		return "", err
+	}
+	path, err := choosePath(paths, -1)
+	if err != nil {
+		return "", err
+	}
+	location, err := computeLowestSeedLocation(puzzle.seeds, path)
+	if err != nil {
+		return "", err
+	}
+	return solver.Int(location), nil
+}
+
+// Adds the flags of `aoc day 5`. The part decides whether seeds are
+// single values (1) or ranges (2).
+func Command(flags *flag.FlagSet) func(input io.Reader, part int) error {
+	fromFlag := flags.String("from", "seed", "Category of the seed values")
+	toFlag := flags.String("to", "location", "Category to map the seed values to")
+	pathFlag := flags.Int("path", -1, "Index of the category path to use, if there are several")
+	comparePathsFlag := flags.Bool("compare_paths", false, "Print the lowest location for every category path")
+	traceFlag := flags.String("trace", "", "Print how seed ranges map at every step, as `text` or `json`")
+	renderFlag := flags.String("render", "", "Draw the seed-to-location mapping as `svg` or `html` instead of solving")
+	validateFlag := flags.Bool("validate", false, "Report overlapping, empty and duplicate range maps instead of solving")
+
+	return func(input io.Reader, part int) error {
+		puzzle, err := loadPuzzle(input, part == 2)
+		if err != nil {
+			return err
+		}
+
+		if *validateFlag {
+			valid := true
+			for _, issue := range validatePuzzle(puzzle) {
+				fmt.Println(issue)
+				if issue.kind != rangeGap {
+					valid = false
+				}
+			}
+			if !valid {
+				return errors.New("Found invalid range maps")
+			}
+			return nil
+		}
+
+		paths, err := findCategoryPaths(puzzle.srcMaps, *fromFlag, *toFlag)
+		if err != nil {
+			return err
+		}
+
+		if *comparePathsFlag {
+			for i, path := range paths {
+				location, err := computeLowestSeedLocation(puzzle.seeds, path)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%d: %v: %d\n", i, path, location)
+			}
+			return nil
+		}
+
+		path, err := choosePath(paths, *pathFlag)
+		if err != nil {
+			return err
+		}
+
+		switch *renderFlag {
+		case "":
+		case "svg":
+			writeSVG(os.Stdout, puzzle.seeds, path)
+			return nil
+		case "html":
+			writeHTML(os.Stdout, puzzle.seeds, path)
+			return nil
+		default:
+			return solver.Errorf(solver.UsageError, "Unknown --render format: %s", *renderFlag)
+		}
+
+		location, err := computeLowestSeedLocation(puzzle.seeds, path)
+		if err != nil {
+			return err
+		}
+
+		switch *traceFlag {
+		case "":
+		case "text":
+			writeTraceText(os.Stdout, traceSeedLocations(puzzle.seeds, path))
+		case "json":
+			trace := struct {
+				Path           string      `json:"path"`
+				Steps          []traceStep `json:"steps"`
+				LowestLocation int         `json:"lowest_location"`
+			}{path.String(), traceSeedLocations(puzzle.seeds, path), location}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			encoder.SetEscapeHTML(false)
+			if err := encoder.Encode(trace); err != nil {
+				return err
+			}
+			return nil
+		default:
+			return solver.Errorf(solver.UsageError, "Unknown --trace format: %s", *traceFlag)
+		}
+		fmt.Println(location)
+		return nil
+	}
+}
@@ -0,0 +1,328 @@
+package day5
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dinord/aoc23/parse"
+)
+
+// Builds category maps without range maps from names like `seed-to-soil`.
+func categoryMaps(names ...string) map[string][]categoryMap {
+	srcMaps := make(map[string][]categoryMap)
+	for i, name := range names {
+		src, dst, _ := strings.Cut(name, "-to-")
+		srcMaps[src] = append(srcMaps[src], categoryMap{src: src, dst: dst, line: i + 1})
+	}
+	return srcMaps
+}
+
+func TestFindCategoryPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		maps  []string
+		from  string
+		to    string
+		want  []string
+		error string
+	}{
+		{
+			name: "single path",
+			maps: []string{"seed-to-soil", "soil-to-location"},
+			from: "seed", to: "location",
+			want: []string{"seed -> soil -> location"},
+		},
+		{
+			name: "multiple paths",
+			maps: []string{"seed-to-soil", "seed-to-water", "soil-to-location", "water-to-location", "soil-to-water"},
+			from: "seed", to: "location",
+			want: []string{"seed -> soil -> location", "seed -> soil -> water -> location", "seed -> water -> location"},
+		},
+		{
+			name: "cycle",
+			maps: []string{"seed-to-soil", "soil-to-water", "water-to-soil", "water-to-location"},
+			from: "seed", to: "location",
+			error: "cycle: soil -> water -> soil",
+		},
+		{
+			name: "self-loop",
+			maps: []string{"seed-to-soil", "soil-to-soil", "soil-to-location"},
+			from: "seed", to: "location",
+			error: "cycle: soil -> soil",
+		},
+		{
+			name: "self-loop at the start",
+			maps: []string{"seed-to-seed", "seed-to-location"},
+			from: "seed", to: "location",
+			error: "cycle: seed -> seed",
+		},
+		{
+			name: "unreachable target",
+			maps: []string{"seed-to-soil", "water-to-location"},
+			from: "seed", to: "location",
+			error: "unreachable",
+		},
+		{
+			name: "same source and target",
+			maps: []string{"seed-to-soil", "soil-to-seed"},
+			from: "seed", to: "seed",
+			error: "different source and target",
+		},
+	}
+	for _, tt := range tests {
+		paths, err := findCategoryPaths(categoryMaps(tt.maps...), tt.from, tt.to)
+		if tt.error != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.error) {
+				t.Errorf("%s: got %v, %v, want error containing %q", tt.name, paths, err, tt.error)
+			}
+			continue
+		}
+		if err != nil || fmt.Sprint(paths) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: got %v, %v, want %v", tt.name, paths, err, tt.want)
+		}
+	}
+}
+
+func TestValidatePuzzle(t *testing.T) {
+	type issue struct {
+		kind almanacIssueKind
+		line int
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  []issue
+	}{
+		{
+			name:  "valid",
+			input: "seeds: 1 2\n\nseed-to-soil map:\n10 0 5\n20 5 5\n\nsoil-to-location map:\n0 10 3\n",
+			want:  []issue{},
+		},
+		{
+			name:  "overlap",
+			input: "seeds: 1 2\n\nseed-to-soil map:\n10 0 5\n20 3 5\n",
+			want:  []issue{{overlappingRanges, 5}},
+		},
+		{
+			name:  "overlap with an earlier, longer range",
+			input: "seeds: 1 2\n\nseed-to-soil map:\n10 0 50\n20 60 5\n30 10 5\n",
+			want:  []issue{{rangeGap, 5}, {overlappingRanges, 6}},
+		},
+		{
+			name:  "gap",
+			input: "seeds: 1 2\n\nseed-to-soil map:\n20 7 5\n0 0 5\n",
+			want:  []issue{{rangeGap, 4}},
+		},
+		{
+			name:  "empty ranges",
+			input: "seeds: 1 0 5 3\n\nseed-to-soil map:\n0 0 0\n10 0 5\n",
+			want:  []issue{{emptyRange, 1}, {emptyRange, 4}},
+		},
+		{
+			name:  "empty seed range after blank lines",
+			input: "\n\nseeds: 1 0\n\nseed-to-soil map:\n0 0 5\n",
+			want:  []issue{{emptyRange, 3}},
+		},
+		{
+			name:  "duplicate maps",
+			input: "seeds: 1 2\n\nseed-to-soil map:\n0 0 5\n\nseed-to-water map:\n0 0 5\n\nseed-to-soil map:\n5 0 5\n",
+			want:  []issue{{duplicateCategories, 9}},
+		},
+	}
+	for _, tt := range tests {
+		p, err := loadPuzzle(strings.NewReader(tt.input), true)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := make([]issue, 0)
+		for _, i := range validatePuzzle(p) {
+			got = append(got, issue{i.kind, i.line})
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: got issues %v, want %v", tt.name, validatePuzzle(p), tt.want)
+		}
+	}
+}
+
+const exampleAlmanac = `seeds: 79 14 55 13
+
+seed-to-soil map:
+50 98 2
+52 50 48
+
+soil-to-fertilizer map:
+0 15 37
+37 52 2
+39 0 15
+
+fertilizer-to-water map:
+49 53 8
+0 11 42
+42 0 7
+57 7 4
+
+water-to-light map:
+88 18 7
+18 25 70
+
+light-to-temperature map:
+45 77 23
+81 45 19
+68 64 13
+
+temperature-to-humidity map:
+0 69 1
+1 0 69
+
+humidity-to-location map:
+60 56 37
+56 93 4
+`
+
+func TestTraceSeedLocations(t *testing.T) {
+	p, err := loadPuzzle(strings.NewReader(exampleAlmanac), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths, err := findCategoryPaths(p.srcMaps, "seed", "location")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	writeTraceText(&b, traceSeedLocations(p.seeds, paths[0]))
+	want := `seed -> soil (line 3)
+  in:  [79, 93) [55, 68)
+    [79, 93) -> [81, 95) by line 5
+    [55, 68) -> [57, 70) by line 5
+  out: [81, 95) [57, 70)
+soil -> fertilizer (line 7)
+  in:  [81, 95) [57, 70)
+    [81, 95) -> [81, 95) unmapped
+    [57, 70) -> [57, 70) unmapped
+  out: [81, 95) [57, 70)
+fertilizer -> water (line 12)
+  in:  [81, 95) [57, 70)
+    [81, 95) -> [81, 95) unmapped
+    [57, 61) -> [53, 57) by line 13
+    [61, 70) -> [61, 70) unmapped
+  out: [81, 95) [53, 57) [61, 70)
+water -> light (line 18)
+  in:  [81, 95) [53, 57) [61, 70)
+    [81, 95) -> [74, 88) by line 20
+    [53, 57) -> [46, 50) by line 20
+    [61, 70) -> [54, 63) by line 20
+  out: [74, 88) [46, 50) [54, 63)
+light -> temperature (line 22)
+  in:  [74, 88) [46, 50) [54, 63)
+    [77, 88) -> [45, 56) by line 23
+    [74, 77) -> [78, 81) by line 25
+    [46, 50) -> [82, 86) by line 24
+    [54, 63) -> [90, 99) by line 24
+  out: [45, 56) [78, 81) [82, 86) [90, 99)
+temperature -> humidity (line 27)
+  in:  [45, 56) [78, 81) [82, 86) [90, 99)
+    [45, 56) -> [46, 57) by line 29
+    [78, 81) -> [78, 81) unmapped
+    [82, 86) -> [82, 86) unmapped
+    [90, 99) -> [90, 99) unmapped
+  out: [46, 57) [78, 81) [82, 86) [90, 99)
+humidity -> location (line 31)
+  in:  [46, 57) [78, 81) [82, 86) [90, 99)
+    [56, 57) -> [60, 61) by line 32
+    [46, 56) -> [46, 56) unmapped
+    [78, 81) -> [82, 85) by line 32
+    [82, 86) -> [86, 90) by line 32
+    [90, 93) -> [94, 97) by line 32
+    [93, 97) -> [56, 60) by line 33
+    [97, 99) -> [97, 99) unmapped
+  out: [60, 61) [46, 56) [82, 85) [86, 90) [94, 97) [56, 60) [97, 99)
+`
+	if got := b.String(); got != want {
+		t.Errorf("writeTraceText() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRangeEnd(t *testing.T) {
+	tests := []struct {
+		start, length int
+		want          int
+		ok            bool
+	}{
+		{0, 0, 0, true},
+		{5, 3, 8, true},
+		{math.MaxInt - 1, 1, math.MaxInt, true},
+		{math.MaxInt, 0, math.MaxInt, true},
+		{math.MaxInt, 1, 0, false},
+		{1, math.MaxInt, 0, false},
+		{-1, 1, 0, false},
+	}
+	for _, tt := range tests {
+		got, err := rangeEnd(tt.start, tt.length)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("rangeEnd(%d, %d) = %d, %v, want %d and ok %v", tt.start, tt.length, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+// Values near math.MaxInt must parse exactly, or fail instead of wrapping.
+func TestParseNearMaxInt(t *testing.T) {
+	const top = math.MaxInt
+	rangeMaps := []struct {
+		line string
+		ok   bool
+	}{
+		{fmt.Sprintf("0 %d 1", top-1), true},
+		{fmt.Sprintf("%d 0 1", top-1), true},
+		{fmt.Sprintf("0 %d 1", top), false},
+		{fmt.Sprintf("%d 0 1", top), false},
+		{fmt.Sprintf("0 0 %d", top), true},
+		{fmt.Sprintf("0 1 %d", top), false},
+		{"0 -1 1", false},
+		{"0 99999999999999999999 1", false},
+	}
+	for _, tt := range rangeMaps {
+		if _, err := parseRangeMap(parse.Field{Text: tt.line, Col: 1}); (err == nil) != tt.ok {
+			t.Errorf("parseRangeMap(%q) = %v, want ok %v", tt.line, err, tt.ok)
+		}
+	}
+
+	seeds := []struct {
+		line     string
+		asRanges bool
+		ok       bool
+	}{
+		{fmt.Sprintf("seeds: %d", top-1), false, true},
+		{fmt.Sprintf("seeds: %d", top), false, false},
+		{fmt.Sprintf("seeds: %d 7", top-7), true, true},
+		{fmt.Sprintf("seeds: %d 8", top-7), true, false},
+		{"seeds: -1 3", true, false},
+		{"seeds: 99999999999999999999", false, false},
+	}
+	for _, tt := range seeds {
+		if _, err := parseSeeds(parse.Field{Text: tt.line, Col: 1}, tt.asRanges); (err == nil) != tt.ok {
+			t.Errorf("parseSeeds(%q, %v) = %v, want ok %v", tt.line, tt.asRanges, err, tt.ok)
+		}
+	}
+
+	// Values beyond int64 are reported as overflows, not as malformed.
+	_, err := parseRangeMap(parse.Field{Text: "0 99999999999999999999 1", Col: 1})
+	if err == nil || !strings.Contains(err.Error(), "overflows") {
+		t.Errorf("parseRangeMap() = %v, want an overflow error", err)
+	}
+
+	input := fmt.Sprintf("seeds: %d 7\n\nseed-to-location map:\n%d %d 3\n", top-7, top-10, top-5)
+	got, err := solution{}.Part2(strings.NewReader(input))
+	if want := fmt.Sprint(top - 10); err != nil || string(got) != want {
+		t.Errorf("Part2(%q) = %q, %v, want %s", input, got, err, want)
+	}
+}
+
+func TestEmptySeedRanges(t *testing.T) {
+	input := "seeds: 79 0\n\nseed-to-location map:\n0 0 100\n"
+	got, err := solution{}.Part2(strings.NewReader(input))
+	if err == nil {
+		t.Errorf("Part2(%q) = %q, want an error", input, got)
+	}
+}
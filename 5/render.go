@@ -0,0 +1,133 @@
+package day5
+
+import (
+	"fmt"
+	"html"
+	"io"
+
+	"github.com/dinord/aoc23/interval"
+)
+
+const (
+	chartMargin      = 60
+	chartAxisSpacing = 200
+	chartHeight      = 600
+	// Ranges thinner than this are widened so they stay visible.
+	chartMinBandHeight = 0.5
+)
+
+// Maps almanac values to vertical positions, with 0 at the top.
+type chartScale struct {
+	maxValue int
+}
+
+func (s chartScale) y(value int) float64 {
+	if s.maxValue == 0 {
+		return chartMargin
+	}
+	return chartMargin + float64(value)/float64(s.maxValue)*chartHeight
+}
+
+func (s chartScale) band(r interval.Range) (top float64, bottom float64) {
+	top, bottom = s.y(r.Start), s.y(r.End)
+	if bottom-top < chartMinBandHeight {
+		bottom = top + chartMinBandHeight
+	}
+	return top, bottom
+}
+
+func axisX(i int) float64 {
+	return float64(chartMargin + i*chartAxisSpacing)
+}
+
+// Draws `src` on axis `i` flowing into `dst` on axis `i+1`.
+func writeTrapezoid(w io.Writer, i int, src interval.Range, dst interval.Range, scale chartScale, class string) {
+	srcTop, srcBottom := scale.band(src)
+	dstTop, dstBottom := scale.band(dst)
+	fmt.Fprintf(w, `<polygon class="%s" points="%.1f,%.2f %.1f,%.2f %.1f,%.2f %.1f,%.2f"><title>%v -> %v</title></polygon>`+"\n",
+		class, axisX(i), srcTop, axisX(i+1), dstTop, axisX(i+1), dstBottom, axisX(i), srcBottom, src, dst)
+}
+
+func findChartScale(seeds []interval.Range, path categoryPath, steps []traceStep) chartScale {
+	var scale chartScale
+	for _, r := range seeds {
+		scale.maxValue = max(scale.maxValue, r.End)
+	}
+	for _, cm := range path {
+		for _, m := range cm.rangeMaps {
+			scale.maxValue = max(scale.maxValue, m.src.End, m.dst.End)
+		}
+	}
+	for _, step := range steps {
+		for _, r := range step.Output {
+			scale.maxValue = max(scale.maxValue, r.End)
+		}
+	}
+	return scale
+}
+
+// Draws every category on `path` as a vertical number line, the range maps
+// between neighbouring categories as shaded bands and the seed ranges
+// flowing through them on top.
+func writeSVG(w io.Writer, seeds []interval.Range, path categoryPath) {
+	steps := traceSeedLocations(seeds, path)
+	locations := findSeedLocations(seeds, path)
+	scale := findChartScale(seeds, path, steps)
+
+	width := 2*chartMargin + len(path)*chartAxisSpacing
+	height := 2*chartMargin + chartHeight
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+	fmt.Fprintln(w, `<style>
+.map { fill: #4682b4; fill-opacity: 0.25; stroke: #4682b4; stroke-opacity: 0.5; stroke-width: 0.5; }
+.seed { fill: #ff8c00; fill-opacity: 0.6; }
+.location { stroke: #d62728; stroke-width: 4; }
+.axis { stroke: #333; stroke-width: 1; }
+</style>`)
+
+	for i, cm := range path {
+		for _, m := range cm.rangeMaps {
+			if m.src.End > m.src.Start {
+				writeTrapezoid(w, i, m.src, m.dst, scale, "map")
+			}
+		}
+	}
+	for i, step := range steps {
+		for _, p := range step.Pieces {
+			writeTrapezoid(w, i, p.Src, p.Dst, scale, "seed")
+		}
+	}
+
+	names := make([]string, 0, len(path)+1)
+	if len(path) > 0 {
+		names = append(names, path[0].src)
+	}
+	for _, cm := range path {
+		names = append(names, cm.dst)
+	}
+	for i, name := range names {
+		x := axisX(i)
+		fmt.Fprintf(w, `<line class="axis" x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f"/>`+"\n",
+			x, scale.y(0), x, scale.y(scale.maxValue))
+		fmt.Fprintf(w, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n",
+			x, chartMargin/2, html.EscapeString(name))
+	}
+	fmt.Fprintf(w, `<text x="%d" y="%.1f" text-anchor="end">0</text>`+"\n", chartMargin-5, scale.y(0))
+	fmt.Fprintf(w, `<text x="%d" y="%.1f" text-anchor="end">%d</text>`+"\n", chartMargin-5, scale.y(scale.maxValue), scale.maxValue)
+
+	last := axisX(len(path))
+	for _, r := range locations {
+		top, bottom := scale.band(r)
+		fmt.Fprintf(w, `<line class="location" x1="%.1f" y1="%.2f" x2="%.1f" y2="%.2f"><title>%v</title></line>`+"\n",
+			last, top, last, bottom, r)
+	}
+	fmt.Fprintln(w, "</svg>")
+}
+
+func writeHTML(w io.Writer, seeds []interval.Range, path categoryPath) {
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n",
+		html.EscapeString(path.String()))
+	fmt.Fprintf(w, "<h1>%s</h1>\n", html.EscapeString(path.String()))
+	writeSVG(w, seeds, path)
+	fmt.Fprintln(w, "</body>\n</html>")
+}
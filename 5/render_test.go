@@ -0,0 +1,54 @@
+package day5
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// Fails unless `s` is well formed XML.
+func checkXML(t *testing.T, s string) {
+	t.Helper()
+	decoder := xml.NewDecoder(strings.NewReader(s))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("Malformed XML: %v\n%s", err, s)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	// Category names are escaped.
+	input := strings.Replace(exampleAlmanac, "humidity", "<humidity & co>", -1)
+	p, err := loadPuzzle(strings.NewReader(input), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths, err := findCategoryPaths(p.srcMaps, "seed", "location")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var svg strings.Builder
+	writeSVG(&svg, p.seeds, paths[0])
+	checkXML(t, svg.String())
+	if !strings.HasPrefix(svg.String(), "<svg ") || !strings.Contains(svg.String(), "&lt;humidity &amp; co&gt;") {
+		t.Errorf("writeSVG() = %s, want an SVG with escaped category names", svg.String())
+	}
+
+	var page strings.Builder
+	writeHTML(&page, p.seeds, paths[0])
+	html := page.String()
+	if !strings.HasPrefix(html, "<!DOCTYPE html>\n") || !strings.HasSuffix(html, "</html>\n") {
+		t.Errorf("writeHTML() = %s, want a complete HTML page", html)
+	}
+	if !strings.Contains(html, svg.String()) {
+		t.Errorf("writeHTML() = %s, want it to embed the SVG", html)
+	}
+}
@@ -0,0 +1,249 @@
+package day6
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/dinord/aoc23/parse"
+	"github.com/dinord/aoc23/solver"
+)
+
+// Kerned numbers can get arbitrarily long, so all numbers are parsed as big.Int.
+func parseBigInt(s string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("Not an integer: %s", s)
+	}
+	return i, nil
+}
+
+// Scans a line like `Time: 7 15 30` as the single number 71530.
+func scanPrefixedKernedInt(scanner *parse.Scanner, key string) (*big.Int, error) {
+	line, err := scanner.Expect(fmt.Sprintf("`%s:`", key))
+	if err != nil {
+		return nil, err
+	}
+	value, err := line.Value(key)
+	if err != nil {
+		return nil, scanner.Wrap(err)
+	}
+
+	var digits strings.Builder
+	for _, f := range value.Fields() {
+		digits.WriteString(f.Text)
+	}
+	i, err := parseBigInt(digits.String())
+	if err != nil {
+		return nil, scanner.Wrap(&parse.Error{Col: value.Col, Err: err})
+	}
+	return i, nil
+}
+
+// Scans a line like `Time: 7 15 30` as separate numbers.
+func scanPrefixedInts(scanner *parse.Scanner, key string) ([]*big.Int, error) {
+	line, err := scanner.Expect(fmt.Sprintf("`%s:`", key))
+	if err != nil {
+		return nil, err
+	}
+	value, err := line.Value(key)
+	if err != nil {
+		return nil, scanner.Wrap(err)
+	}
+
+	fields := value.Fields()
+	ints := make([]*big.Int, len(fields))
+	for i, f := range fields {
+		var err error
+		ints[i], err = parseBigInt(f.Text)
+		if err != nil {
+			return nil, scanner.Wrap(&parse.Error{Col: f.Col, Err: err})
+		}
+	}
+	return ints, nil
+}
+
+// Computes the number of viable strategies for covering
+// `distMillim` in strictly less than `timeMillis`.
+//
+// The racer's initial velocity is zero.
+// At the start of the race, the racer can choose to either
+// 1) keep holding the pedal and not moving, while gaining
+// 1 millim / millis of speed per millis, or 2) release the
+// pedal and continue moving at the obtained velocity.
+// Viable strategies are all combinations of (1) and (2)
+// that cover `distMillim` in less than `timeMilis`.
+// See boatModel for other ways of gaining speed.
+func numViableStrategies(timeMillis *big.Int, distMillim *big.Int) *big.Int {
+	return puzzleBoatModel.numViableStrategies(timeMillis, distMillim)
+}
+
+func loadTimeAndDistance(input io.Reader) (timeMillis *big.Int, distanceMillim *big.Int, err error) {
+	scanner := parse.NewScanner(input)
+	timeMillis, err = scanPrefixedKernedInt(scanner, "Time")
+	if err != nil {
+		return
+	}
+	distanceMillim, err = scanPrefixedKernedInt(scanner, "Distance")
+	if err != nil {
+		return
+	}
+	return
+}
+
+// Loads one race per column, for part one.
+func loadRaces(input io.Reader) (timesMillis []*big.Int, distancesMillim []*big.Int, err error) {
+	scanner := parse.NewScanner(input)
+	timesMillis, err = scanPrefixedInts(scanner, "Time")
+	if err != nil {
+		return
+	}
+	if len(timesMillis) == 0 {
+		err = scanner.Wrap(errors.New("Expected at least one race time"))
+		return
+	}
+	distancesMillim, err = scanPrefixedInts(scanner, "Distance")
+	if err != nil {
+		return
+	}
+	if len(timesMillis) != len(distancesMillim) {
+		err = fmt.Errorf("Expected as many distances as times, got %d times and %d distances",
+			len(timesMillis), len(distancesMillim))
+	}
+	return
+}
+
+func multiplyViableStrategies(model boatModel, timesMillis []*big.Int, distancesMillim []*big.Int) *big.Int {
+	product := big.NewInt(1)
+	for i := range timesMillis {
+		product.Mul(product, model.numViableStrategies(timesMillis[i], distancesMillim[i]))
+	}
+	return product
+}
+
+// Explains the answer for a single race.
+type raceReport struct {
+	TimeMillis   *big.Int `json:"time_millis"`
+	RecordMillim *big.Int `json:"record_millim"`
+	Viable       *big.Int `json:"viable"`
+	// Bounds of the viable hold times, nil if there are none.
+	MinViableMillis *big.Int `json:"min_viable_millis"`
+	MaxViableMillis *big.Int `json:"max_viable_millis"`
+	BestHoldMillis  *big.Int `json:"best_hold_millis"`
+	BestDistMillim  *big.Int `json:"best_dist_millim"`
+}
+
+func reportRace(model boatModel, timeMillis *big.Int, distMillim *big.Int) raceReport {
+	r := raceReport{TimeMillis: timeMillis, RecordMillim: distMillim}
+	r.Viable = model.numViableStrategies(timeMillis, distMillim)
+	r.MinViableMillis, r.MaxViableMillis, _ = model.viableStrategies(timeMillis, distMillim)
+	r.BestHoldMillis, r.BestDistMillim = model.bestStrategy(timeMillis)
+	return r
+}
+
+func writeReports(w io.Writer, reports []raceReport) {
+	fmt.Fprintf(w, "%-6s %12s %12s %12s %12s %12s %12s %12s\n",
+		"race", "time", "record", "viable", "min hold", "max hold", "best hold", "best dist")
+	for i, r := range reports {
+		minViable, maxViable := "-", "-"
+		if r.MinViableMillis != nil {
+			minViable, maxViable = r.MinViableMillis.String(), r.MaxViableMillis.String()
+		}
+		fmt.Fprintf(w, "%-6d %12v %12v %12v %12s %12s %12v %12v\n", i+1, r.TimeMillis, r.RecordMillim,
+			r.Viable, minViable, maxViable, r.BestHoldMillis, r.BestDistMillim)
+	}
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 6, Solver: solution{}, Command: Command})
+}
+
+type solution struct{}
+
+func (solution) Part1(input io.Reader) (solver.Answer, error) {
+	timesMillis, distancesMillim, err := loadRaces(input)
+	if err != nil {
+		return "", err
+	}
+	return solver.Answer(multiplyViableStrategies(puzzleBoatModel, timesMillis, distancesMillim).String()), nil
+}
+
+func (solution) Part2(input io.Reader) (solver.Answer, error) {
+	timeMillis, distanceMillim, err := loadTimeAndDistance(input)
+	if err != nil {
+		return "", err
+	}
+	return solver.Answer(numViableStrategies(timeMillis, distanceMillim).String()), nil
+}
+
+// Adds the flags of `aoc day 6`. Part 1 has separate races,
+// part 2 one kerned race.
+func Command(flags *flag.FlagSet) func(input io.Reader, part int) error {
+	accelerationFlag := flags.Int64("acceleration", 1, "Speed gained per millis the button is held")
+	startSpeedFlag := flags.Int64("start_speed", 0, "Speed of the boat if the button is not held")
+	maxSpeedFlag := flags.Int64("max_speed", -1, "Speed the boat cannot exceed, or -1 if unlimited")
+	tieWinsFlag := flags.Bool("tie_wins", false, "Count matching the record distance as a win")
+	reportFlag := flags.Bool("report", false, "Print viable hold times and the best hold time of every race")
+	jsonFlag := flags.Bool("json", false, "Print the answer and race reports as JSON")
+
+	return func(input io.Reader, part int) error {
+		model := boatModel{
+			acceleration: big.NewInt(*accelerationFlag),
+			startSpeed:   big.NewInt(*startSpeedFlag),
+			tieWins:      *tieWinsFlag,
+		}
+		if *maxSpeedFlag >= 0 {
+			model.maxSpeed = big.NewInt(*maxSpeedFlag)
+		}
+		if err := model.validate(); err != nil {
+			return &solver.Error{Kind: solver.UsageError, Err: err}
+		}
+
+		var timesMillis, distancesMillim []*big.Int
+		var answer *big.Int
+		if part == 1 {
+			var err error
+			timesMillis, distancesMillim, err = loadRaces(input)
+			if err != nil {
+				return err
+			}
+			answer = multiplyViableStrategies(model, timesMillis, distancesMillim)
+		} else {
+			timeMillis, distanceMillim, err := loadTimeAndDistance(input)
+			if err != nil {
+				return err
+			}
+			timesMillis, distancesMillim = []*big.Int{timeMillis}, []*big.Int{distanceMillim}
+			answer = model.numViableStrategies(timeMillis, distanceMillim)
+		}
+
+		if !*reportFlag && !*jsonFlag {
+			fmt.Println(answer)
+			return nil
+		}
+		reports := make([]raceReport, len(timesMillis))
+		for i := range timesMillis {
+			reports[i] = reportRace(model, timesMillis[i], distancesMillim[i])
+		}
+		if *jsonFlag {
+			output := struct {
+				Races  []raceReport `json:"races"`
+				Answer *big.Int     `json:"answer"`
+			}{reports, answer}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(output); err != nil {
+				return err
+			}
+			return nil
+		}
+		writeReports(os.Stdout, reports)
+		fmt.Println(answer)
+		return nil
+	}
+}
@@ -0,0 +1,147 @@
+package day6
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func bruteForceViableStrategies(timeMillis int64, distMillim int64) int64 {
+	count := int64(0)
+	for x := int64(0); x <= timeMillis; x++ {
+		if x*(timeMillis-x) > distMillim {
+			count++
+		}
+	}
+	return count
+}
+
+func TestNumViableStrategiesMatchesBruteForce(t *testing.T) {
+	for timeMillis := int64(0); timeMillis <= 100; timeMillis++ {
+		for distMillim := int64(-1); distMillim <= timeMillis*timeMillis/4+1; distMillim++ {
+			want := bruteForceViableStrategies(timeMillis, distMillim)
+			got := numViableStrategies(big.NewInt(timeMillis), big.NewInt(distMillim))
+			if got.Cmp(big.NewInt(want)) != 0 {
+				t.Fatalf("numViableStrategies(%d, %d) = %v, want %d", timeMillis, distMillim, got, want)
+			}
+		}
+	}
+}
+
+// Races where t*t exceeds 2^53 and float64 square roots lose precision.
+func TestNumViableStrategiesLargeRaces(t *testing.T) {
+	tests := []struct {
+		timeMillis string
+		distMillim string
+		want       string
+	}{
+		// x * (t - x) > d for exactly x = 10^9.
+		{"2000000000", "999999999999999999", "1"},
+		// Viable for 10^30 - 1 <= x <= 10^30 + 1.
+		{"2000000000000000000000000000000", "999999999999999999999999999999999999999999999999999999999998", "3"},
+		{"2000000000000000000000000000000", "1000000000000000000000000000000000000000000000000000000000000", "0"},
+	}
+	for _, tt := range tests {
+		timeMillis, _ := new(big.Int).SetString(tt.timeMillis, 10)
+		distMillim, _ := new(big.Int).SetString(tt.distMillim, 10)
+		want, _ := new(big.Int).SetString(tt.want, 10)
+		if got := numViableStrategies(timeMillis, distMillim); got.Cmp(want) != 0 {
+			t.Errorf("numViableStrategies(%v, %v) = %v, want %v", timeMillis, distMillim, got, want)
+		}
+	}
+}
+
+func TestBoatModelsMatchBruteForce(t *testing.T) {
+	for _, acceleration := range []int64{0, 1, 3} {
+		for _, startSpeed := range []int64{0, 2} {
+			for _, maxSpeed := range []int64{-1, 2, 5} {
+				if maxSpeed >= 0 && maxSpeed < startSpeed {
+					continue
+				}
+				for _, tieWins := range []bool{false, true} {
+					model := boatModel{
+						acceleration: big.NewInt(acceleration),
+						startSpeed:   big.NewInt(startSpeed),
+						tieWins:      tieWins,
+					}
+					if maxSpeed >= 0 {
+						model.maxSpeed = big.NewInt(maxSpeed)
+					}
+					for timeMillis := int64(0); timeMillis <= 20; timeMillis++ {
+						for distMillim := int64(-1); distMillim <= 3*timeMillis*timeMillis; distMillim++ {
+							want := int64(0)
+							for x := int64(0); x <= timeMillis; x++ {
+								speed := startSpeed + acceleration*x
+								if maxSpeed >= 0 {
+									speed = min(speed, maxSpeed)
+								}
+								dist := speed * (timeMillis - x)
+								if dist > distMillim || (tieWins && dist == distMillim) {
+									want++
+								}
+							}
+							got := model.numViableStrategies(big.NewInt(timeMillis), big.NewInt(distMillim))
+							if got.Cmp(big.NewInt(want)) != 0 {
+								t.Fatalf("%+v: numViableStrategies(%d, %d) = %v, want %d",
+									model, timeMillis, distMillim, got, want)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestReportRace(t *testing.T) {
+	tests := []struct {
+		timeMillis, distMillim                       int64
+		minViable, maxViable, bestHold, bestDistance int64
+	}{
+		{7, 9, 2, 5, 3, 12},
+		{15, 40, 4, 11, 7, 56},
+		{30, 200, 11, 19, 15, 225},
+	}
+	for _, tt := range tests {
+		r := reportRace(puzzleBoatModel, big.NewInt(tt.timeMillis), big.NewInt(tt.distMillim))
+		if r.MinViableMillis.Int64() != tt.minViable || r.MaxViableMillis.Int64() != tt.maxViable ||
+			r.BestHoldMillis.Int64() != tt.bestHold || r.BestDistMillim.Int64() != tt.bestDistance {
+			t.Errorf("reportRace(%d, %d) = %+v, want viable [%d, %d] and best hold %d covering %d",
+				tt.timeMillis, tt.distMillim, r, tt.minViable, tt.maxViable, tt.bestHold, tt.bestDistance)
+		}
+	}
+
+	r := reportRace(puzzleBoatModel, big.NewInt(4), big.NewInt(4))
+	if r.MinViableMillis != nil || r.MaxViableMillis != nil || r.Viable.Sign() != 0 {
+		t.Errorf("reportRace(4, 4) = %+v, want no viable hold times", r)
+	}
+}
+
+func TestLoadTimeAndDistanceLongLines(t *testing.T) {
+	// Kerning spaces longer than any default line buffer.
+	gap := strings.Repeat(" ", 1<<17)
+	input := "Time:" + gap + "7" + gap + "15" + gap + "30\nDistance:" + gap + "9" + gap + "40" + gap + "200\n"
+	timeMillis, distMillim, err := loadTimeAndDistance(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := numViableStrategies(timeMillis, distMillim).Int64(); got != 71503 {
+		t.Errorf("numViableStrategies(%v, %v) = %d, want 71503", timeMillis, distMillim, got)
+	}
+}
+
+func TestLoadRacesErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Time:\nDistance:\n", "Line 1: Expected at least one race time"},
+		{"Time: 7 15\nDistance: 9\n", "Expected as many distances as times, got 2 times and 1 distances"},
+	}
+	for _, tt := range tests {
+		_, _, err := loadRaces(strings.NewReader(tt.input))
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("loadRaces(%q) = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package day6
+
+import (
+	"errors"
+	"math/big"
+)
+
+// How a boat gains speed while the button is held.
+//
+// Holding the button for x millis gives the boat a speed of
+// min(startSpeed + acceleration * x, maxSpeed), which it keeps
+// for the rest of the race.
+type boatModel struct {
+	acceleration *big.Int // millim / millis of speed gained per millis held
+	startSpeed   *big.Int // millim / millis
+	maxSpeed     *big.Int // millim / millis, nil if unlimited
+	// Whether matching the record distance counts as beating it.
+	tieWins bool
+}
+
+// The model from the puzzle statement.
+var puzzleBoatModel = boatModel{
+	acceleration: big.NewInt(1),
+	startSpeed:   big.NewInt(0),
+}
+
+func (m boatModel) validate() error {
+	if m.acceleration.Sign() < 0 {
+		return errors.New("Expected non-negative acceleration")
+	}
+	if m.startSpeed.Sign() < 0 {
+		return errors.New("Expected non-negative start speed")
+	}
+	if m.maxSpeed != nil && m.maxSpeed.Cmp(m.startSpeed) < 0 {
+		return errors.New("Expected max speed of at least the start speed")
+	}
+	return nil
+}
+
+func (m boatModel) speed(holdMillis *big.Int) *big.Int {
+	speed := new(big.Int).Mul(m.acceleration, holdMillis)
+	speed.Add(speed, m.startSpeed)
+	if m.maxSpeed != nil && speed.Cmp(m.maxSpeed) > 0 {
+		speed.Set(m.maxSpeed)
+	}
+	return speed
+}
+
+func (m boatModel) distance(holdMillis *big.Int, timeMillis *big.Int) *big.Int {
+	return new(big.Int).Mul(m.speed(holdMillis), new(big.Int).Sub(timeMillis, holdMillis))
+}
+
+// Computes the number of hold times in [0, `timeMillis`] that beat `distMillim`.
+func (m boatModel) numViableStrategies(timeMillis *big.Int, distMillim *big.Int) *big.Int {
+	minViable, maxViable, ok := m.viableStrategies(timeMillis, distMillim)
+	if !ok {
+		return new(big.Int)
+	}
+	count := new(big.Int).Sub(maxViable, minViable)
+	return count.Add(count, big.NewInt(1))
+}
+
+// Finds the first and last hold times in [0, `timeMillis`] that beat
+// `distMillim`. All hold times in between beat it as well.
+// Returns false if there are none.
+func (m boatModel) viableStrategies(timeMillis *big.Int, distMillim *big.Int) (minViable *big.Int, maxViable *big.Int, ok bool) {
+	if timeMillis.Sign() < 0 {
+		return nil, nil, false
+	}
+	// All distances are integers, so reaching d is the same as beating d - 1.
+	record := distMillim
+	if m.tieWins {
+		record = new(big.Int).Sub(distMillim, big.NewInt(1))
+	}
+	if m.maxSpeed == nil && m.acceleration.Sign() > 0 {
+		return m.viableQuadratic(timeMillis, record)
+	}
+	return m.viableUnimodal(timeMillis, record)
+}
+
+// Finds the shortest hold time that covers the longest distance.
+func (m boatModel) bestStrategy(timeMillis *big.Int) (holdMillis *big.Int, distMillim *big.Int) {
+	holdMillis = m.peak(timeMillis)
+	return holdMillis, m.distance(holdMillis, timeMillis)
+}
+
+// Returns the first hold time after which holding longer does not help.
+func (m boatModel) peak(timeMillis *big.Int) *big.Int {
+	t := timeMillis
+	one := big.NewInt(1)
+	return searchBig(new(big.Int), t, func(x *big.Int) bool {
+		next := new(big.Int).Add(x, one)
+		return next.Cmp(t) > 0 || m.distance(x, t).Cmp(m.distance(next, t)) >= 0
+	})
+}
+
+// Finds viable hold times in closed form, when the distance is
+// a quadratic function of the hold time.
+func (m boatModel) viableQuadratic(timeMillis *big.Int, record *big.Int) (minViable *big.Int, maxViable *big.Int, ok bool) {
+	// x := number of millis the pedal is held
+	// t := `timeMillis`, a := acceleration, v := start speed
+	// viable strategies: (v + a * x) * (t - x) > d
+	// i.e. -a * x^2 + (a * t - v) * x + v * t - d > 0
+	t, d, a, v := timeMillis, record, m.acceleration, m.startSpeed
+	isViable := func(x *big.Int) bool {
+		return m.distance(x, t).Cmp(d) > 0
+	}
+	b := new(big.Int).Mul(a, t)
+	b.Sub(b, v)
+	twoA := new(big.Int).Lsh(a, 1)
+
+	// If the quadratic equation has less than two real solutions,
+	// there are no viable strategies.
+	disc := new(big.Int).Mul(v, t)
+	disc.Sub(disc, d)
+	disc.Mul(disc, new(big.Int).Lsh(a, 2))
+	disc.Add(disc, new(big.Int).Mul(b, b))
+	if disc.Sign() <= 0 {
+		return nil, nil, false
+	}
+	sqrtDisc := new(big.Int).Sqrt(disc)
+
+	// The distance grows up to around the peak, and shrinks after it.
+	peak := new(big.Int).Div(b, twoA)
+	peak.Add(peak, big.NewInt(1))
+	if peak.Cmp(t) > 0 {
+		peak.Set(t)
+	}
+
+	// Estimate the bounds from the roots (b -+ sqrt(disc)) / 2a,
+	// rounding the square root down, and then fix the estimates
+	// so they are exactly the first and last viable strategies.
+	one := big.NewInt(1)
+	minViable = new(big.Int).Sub(b, sqrtDisc)
+	minViable.Div(minViable, twoA)
+	if minViable.Sign() < 0 {
+		minViable.SetInt64(0)
+	}
+	for !isViable(minViable) {
+		minViable.Add(minViable, one)
+		// Both roots are between the same two integers.
+		if minViable.Cmp(peak) > 0 {
+			return nil, nil, false
+		}
+	}
+	for minViable.Sign() > 0 && isViable(new(big.Int).Sub(minViable, one)) {
+		minViable.Sub(minViable, one)
+	}
+
+	maxViable = new(big.Int).Add(b, sqrtDisc)
+	maxViable.Div(maxViable, twoA)
+	if maxViable.Cmp(t) > 0 {
+		maxViable.Set(t)
+	}
+	for maxViable.Cmp(minViable) >= 0 && !isViable(maxViable) {
+		maxViable.Sub(maxViable, one)
+	}
+	for maxViable.Cmp(t) < 0 && isViable(new(big.Int).Add(maxViable, one)) {
+		maxViable.Add(maxViable, one)
+	}
+	return minViable, maxViable, true
+}
+
+// Finds viable hold times with binary search. This works for any model,
+// since the distance first grows and then shrinks with the hold time.
+func (m boatModel) viableUnimodal(timeMillis *big.Int, record *big.Int) (minViable *big.Int, maxViable *big.Int, ok bool) {
+	t := timeMillis
+	peak := m.peak(t)
+	if m.distance(peak, t).Cmp(record) <= 0 {
+		return nil, nil, false
+	}
+
+	minViable = searchBig(new(big.Int), peak, func(x *big.Int) bool {
+		return m.distance(x, t).Cmp(record) > 0
+	})
+	// The first hold time after the peak that is no longer viable.
+	maxViable = searchBig(peak, t, func(x *big.Int) bool {
+		return m.distance(x, t).Cmp(record) <= 0
+	})
+	return minViable, maxViable.Sub(maxViable, big.NewInt(1)), true
+}
+
+// Returns the first x in [lo, hi] for which `pred` holds, or hi + 1 if none.
+// `pred` must be false up to some x and true from there on.
+func searchBig(lo *big.Int, hi *big.Int, pred func(*big.Int) bool) *big.Int {
+	lo = new(big.Int).Set(lo)
+	end := new(big.Int).Add(hi, big.NewInt(1))
+	for lo.Cmp(end) < 0 {
+		mid := new(big.Int).Add(lo, end)
+		mid.Rsh(mid, 1)
+		if pred(mid) {
+			end = mid
+		} else {
+			lo = mid.Add(mid, big.NewInt(1))
+		}
+	}
+	return lo
+}
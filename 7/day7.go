@@ -0,0 +1,358 @@
+package day7
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dinord/aoc23/parse"
+	"github.com/dinord/aoc23/solver"
+)
+
+type handType = int
+
+// Hand types are indices into the ruleset's categories, starting from 1.
+// These are the hand types under camelCardCategories.
+const (
+	UnknownHand handType = iota
+	HighCard
+	OnePair
+	TwoPair
+	ThreeOfAKind
+	FullHouse
+	FourOfAKind
+	FiveOfAKind
+)
+
+type hand struct {
+	cards []int
+	bid   int
+	line  int // in the input, starting from 1
+	// Orders hands by strength, see strengthKey.
+	key uint64
+}
+
+func (this hand) Type(rules ruleset) handType {
+	t, _ := this.bestType(rules)
+	return t
+}
+
+// Returns the strongest type the hand can have, and the cards its wild
+// cards act as, from the strongest. Categories may be in any order, so
+// wild cards are not simply added to the most common card: every way of
+// spreading them is tried, preferring stronger cards among equal types.
+func (this hand) bestType(rules ruleset) (t handType, substitutes []int) {
+	cardCounts := make(map[int]int)
+	wildCount := 0
+	for _, card := range this.cards {
+		if rules.wildRanks[card] {
+			wildCount++
+			continue
+		}
+		cardCounts[card]++
+	}
+	if wildCount == 0 {
+		return rules.classify(cardCounts), nil
+	}
+
+	// Only counts matter, so wild cards act as cards of the hand, or as
+	// the strongest other cards, enough for every wild card to differ.
+	candidates := make([]int, 0, len(this.cards))
+	fresh := 0
+	for card := len(rules.cardSymbols); card >= 1; card-- {
+		if cardCounts[card] > 0 {
+			candidates = append(candidates, card)
+		} else if !rules.wildRanks[card] && fresh < wildCount {
+			candidates = append(candidates, card)
+			fresh++
+		}
+	}
+	if len(candidates) == 0 {
+		// Every card is wild, so wild cards can only act as themselves.
+		for _, card := range this.cards {
+			cardCounts[card]++
+		}
+		return rules.classify(cardCounts), nil
+	}
+
+	current := make([]int, wildCount)
+	// Picks the cards of wild cards from index `i` on among candidates[first:],
+	// so every multiset of candidates is tried once, the strongest first.
+	var try func(i int, first int)
+	try = func(i int, first int) {
+		if t == handType(len(rules.categories)) {
+			return // nothing is stronger
+		}
+		if i == wildCount {
+			for _, card := range current {
+				cardCounts[card]++
+			}
+			if ct := rules.classify(cardCounts); ct > t {
+				t, substitutes = ct, slices.Clone(current)
+			}
+			for _, card := range current {
+				cardCounts[card]--
+				if cardCounts[card] == 0 {
+					delete(cardCounts, card)
+				}
+			}
+			return
+		}
+		for j := first; j < len(candidates); j++ {
+			current[i] = candidates[j]
+			try(i+1, j)
+		}
+	}
+	try(0, 0)
+	return t, substitutes
+}
+
+// Packs the hand type and the card ranks into a single integer,
+// so that stronger hands have larger keys.
+func (this hand) strengthKey(rules ruleset) uint64 {
+	key := uint64(this.Type(rules))
+	for _, card := range this.cards {
+		key = key<<rules.cardBits | uint64(card)
+	}
+	return key
+}
+
+func (this hand) Less(other hand) bool {
+	return this.key < other.key
+}
+
+func parseHand(s string, rules ruleset) (h hand, err error) {
+	fields := parse.Field{Text: s, Col: 1}.Fields()
+	if len(fields) == 0 {
+		return h, &parse.Error{Col: 1, Err: errors.New("Expected `<cards> <bid>`, got an empty line")}
+	}
+	if len(fields) > 2 {
+		return h, &parse.Error{Col: fields[2].Col, Err: fmt.Errorf("Unexpected field after the bid: %s", fields[2].Text)}
+	}
+
+	cards := fields[0]
+	if n := utf8.RuneCountInString(cards.Text); n != rules.handSize {
+		return h, &parse.Error{Col: cards.Col, Err: fmt.Errorf("Expected %d cards, got %d: %s", rules.handSize, n, cards.Text)}
+	}
+	h.cards = make([]int, 0, rules.handSize)
+	for i, c := range []rune(cards.Text) {
+		rank, ok := rules.cardRanks[c]
+		if !ok {
+			return h, &parse.Error{Col: cards.Col + i, Err: fmt.Errorf("Not a card: %c", c)}
+		}
+		h.cards = append(h.cards, rank)
+	}
+	h.key = h.strengthKey(rules)
+
+	if len(fields) < 2 {
+		return h, &parse.Error{Col: utf8.RuneCountInString(s) + 1, Err: errors.New("Expected a bid after the cards")}
+	}
+	bid := fields[1]
+	h.bid, err = strconv.Atoi(bid.Text)
+	if err != nil {
+		return h, &parse.Error{Col: bid.Col, Err: fmt.Errorf("Expected an integer bid, got: %s", bid.Text)}
+	}
+	if h.bid < 0 {
+		return h, &parse.Error{Col: bid.Col, Err: fmt.Errorf("Expected a non-negative bid, got: %d", h.bid)}
+	}
+	return h, nil
+}
+
+// Loads hands with `parse`, e.g. parseHand or parsePokerHand.
+func loadHands(input io.Reader, parseLine func(line string) (hand, error)) (hands []hand, err error) {
+	scanner := parse.NewScanner(input)
+	hands = make([]hand, 0, 10) // arbitrary capacity
+	for scanner.Scan() {
+		token := scanner.Text()
+		if strings.TrimSpace(token) == "" {
+			continue
+		}
+		var h hand
+		h, err = parseLine(token)
+		if err != nil {
+			return nil, scanner.Wrap(err)
+		}
+		h.line = scanner.Line()
+		hands = append(hands, h)
+	}
+	return hands, scanner.Err()
+}
+
+// Finds hands that tie, i.e. have the same strength key. For Camel Cards
+// they have the same cards, for poker equal ranks. Their order after
+// sorting, and thus their winnings, depends on their order in the input.
+// Returns the lines of each group of tied hands.
+func findTiedHands(hands []hand) [][]int {
+	linesByKey := make(map[uint64][]int)
+	keys := make([]uint64, 0, len(hands))
+	for _, h := range hands {
+		if _, ok := linesByKey[h.key]; !ok {
+			keys = append(keys, h.key)
+		}
+		linesByKey[h.key] = append(linesByKey[h.key], h.line)
+	}
+
+	ties := make([][]int, 0)
+	for _, key := range keys {
+		if lines := linesByKey[key]; len(lines) > 1 {
+			ties = append(ties, lines)
+		}
+	}
+	return ties
+}
+
+func totalScore(hands []hand) int {
+	// Keep duplicate hands in input order, so the score does not change between runs.
+	sort.SliceStable(hands, func(i, j int) bool { return hands[i].Less(hands[j]) })
+	score := 0
+	for i, h := range hands {
+		score += (i + 1) * h.bid
+	}
+	return score
+}
+
+// Replaces parts of `rules` with the ruleset file and rule flags that are set.
+func customizeRuleset(rules ruleset, rulesPath string, cards string, wild string, handSize int, categories string) (ruleset, error) {
+	var err error
+	if rulesPath != "" {
+		rules, err = loadRuleset(rulesPath)
+		if err != nil {
+			return rules, err
+		}
+	}
+	if cards == "" && wild == "" && handSize == 0 && categories == "" {
+		return rules, nil
+	}
+
+	// Rebuild the ruleset from its parts, so the flags are validated together.
+	if cards == "" {
+		cards = string(rules.cardSymbols)
+	}
+	if wild == "" {
+		for rank := range rules.wildRanks {
+			wild += string(rules.cardSymbols[rank-1])
+		}
+	} else if wild == "-" {
+		wild = ""
+	}
+	if handSize == 0 {
+		handSize = rules.handSize
+	}
+	parsedCategories := rules.categories
+	if categories != "" {
+		parsedCategories, err = parseHandCategories(categories)
+		if err != nil {
+			return rules, err
+		}
+	}
+	return newRuleset(cards, wild, handSize, parsedCategories)
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 7, Solver: solution{}, Command: Command})
+}
+
+type solution struct{}
+
+func (solution) Part1(input io.Reader) (solver.Answer, error) {
+	return solve(input, jackRuleset)
+}
+
+func (solution) Part2(input io.Reader) (solver.Answer, error) {
+	return solve(input, jokerRuleset)
+}
+
+func solve(input io.Reader, rules ruleset) (solver.Answer, error) {
+	hands, err := loadHands(input, func(line string) (hand, error) { return parseHand(line, rules) })
+	if err != nil {
+		return "", err
+	}
+	if unknown := countUnknownHands(hands, rules); unknown > 0 {
+		return "", fmt.Errorf("%d hands match no hand category", unknown)
+	}
+	return solver.Int(totalScore(hands)), nil
+}
+
+// Adds the flags of `aoc day 7`. In part 1 J is a Jack, in part 2 a Joker.
+func Command(flags *flag.FlagSet) func(input io.Reader, part int) error {
+	rulesPathFlag := flags.String("rules_path", "", "Path to a ruleset file, replacing the rules of --part")
+	cardsFlag := flags.String("cards", "", "Card symbols from the weakest to the strongest")
+	wildFlag := flags.String("wild", "", "Card symbols that are wild, or `-` for none")
+	handSizeFlag := flags.Int("hand_size", 0, "Number of cards per hand")
+	categoriesFlag := flags.String("categories", "",
+		"Hand categories from the weakest to the strongest, e.g. `pair: 2; two pair: 2 2`")
+	evaluatorFlag := flags.String("evaluator", "camel", "How hands are ranked: `camel` for Camel Cards, or `poker`")
+	warnDuplicatesFlag := flags.Bool("warn_duplicates", false, "Warn about hands that tie, whose ranks follow input order")
+	explainFlag := flags.String("explain", "", "Print how every hand ranks, as a `table` or `json`")
+
+	return func(input io.Reader, part int) error {
+		rules := jokerRuleset
+		if part == 1 {
+			rules = jackRuleset
+		}
+		rules, err := customizeRuleset(rules, *rulesPathFlag, *cardsFlag, *wildFlag, *handSizeFlag, *categoriesFlag)
+		if err != nil {
+			// Failing to read the ruleset file is not a usage error.
+			if solver.KindOf(err) == solver.IOError {
+				return err
+			}
+			return &solver.Error{Kind: solver.UsageError, Err: err}
+		}
+
+		parseLine := func(line string) (hand, error) { return parseHand(line, rules) }
+		switch *evaluatorFlag {
+		case "camel":
+		case "poker":
+			if *explainFlag != "" {
+				return solver.Errorf(solver.UsageError, "Flag --explain only supports --evaluator camel")
+			}
+			parseLine = parsePokerHand
+		default:
+			return solver.Errorf(solver.UsageError, "Unknown --evaluator: %s", *evaluatorFlag)
+		}
+
+		hands, err := loadHands(input, parseLine)
+		if err != nil {
+			return err
+		}
+		if *warnDuplicatesFlag {
+			for _, lines := range findTiedHands(hands) {
+				log.Printf("Warning: Lines %v tie, their ranks follow input order", lines)
+			}
+		}
+		score := totalScore(hands)
+		unknown := 0
+		if *evaluatorFlag == "camel" {
+			unknown = countUnknownHands(hands, rules)
+		}
+
+		switch *explainFlag {
+		case "":
+			if unknown > 0 {
+				return fmt.Errorf("%d hands match no hand category, see --explain", unknown)
+			}
+			fmt.Println(score)
+		case "table", "json":
+			explanations := explainHands(hands, rules)
+			if *explainFlag == "table" {
+				writeExplanationTable(os.Stdout, explanations, score)
+			} else if err := writeExplanationJSON(os.Stdout, explanations, score); err != nil {
+				return err
+			}
+			if unknown > 0 {
+				return fmt.Errorf("%d hands match no hand category", unknown)
+			}
+		default:
+			return solver.Errorf(solver.UsageError, "Unknown --explain format: %s", *explainFlag)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,215 @@
+package day7
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dinord/aoc23/parse"
+)
+
+func randomHands(n int, rules ruleset) []hand {
+	random := rand.New(rand.NewSource(1))
+	hands := make([]hand, n)
+	for i := range hands {
+		hands[i].cards = make([]int, rules.handSize)
+		for j := range hands[i].cards {
+			hands[i].cards[j] = 1 + random.Intn(len(rules.cardRanks))
+		}
+		hands[i].bid = random.Intn(1000)
+		hands[i].key = hands[i].strengthKey(rules)
+	}
+	return hands
+}
+
+// Compares hands by type and then card by card, without strength keys.
+func lessByType(this hand, other hand, rules ruleset) bool {
+	thisType, otherType := this.Type(rules), other.Type(rules)
+	if thisType != otherType {
+		return thisType < otherType
+	}
+	return slices.Compare(this.cards, other.cards) < 0
+}
+
+func TestStrengthKeyMatchesTypeOrder(t *testing.T) {
+	for _, rules := range []ruleset{jackRuleset, jokerRuleset} {
+		hands := randomHands(1000, rules)
+		for i := 1; i < len(hands); i++ {
+			a, b := hands[i-1], hands[i]
+			if a.Less(b) != lessByType(a, b, rules) || b.Less(a) != lessByType(b, a, rules) {
+				t.Fatalf("Strength keys order %v and %v differently from their types", a.cards, b.cards)
+			}
+		}
+	}
+}
+
+func TestTotalScore(t *testing.T) {
+	lines := []string{"32T3K 765", "T55J5 684", "KK677 28", "KTJJT 220", "QQQJA 483"}
+	for _, tt := range []struct {
+		rules ruleset
+		want  int
+	}{{jackRuleset, 6440}, {jokerRuleset, 5905}} {
+		hands := make([]hand, len(lines))
+		for i, line := range lines {
+			var err error
+			if hands[i], err = parseHand(line, tt.rules); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := totalScore(hands); got != tt.want {
+			t.Errorf("totalScore() = %d, want %d", got, tt.want)
+		}
+	}
+}
+
+const benchmarkHands = 100000
+
+// Sorts the way totalScore did before hands had strength keys.
+func BenchmarkSortByType(b *testing.B) {
+	hands := randomHands(benchmarkHands, jokerRuleset)
+	for i := 0; i < b.N; i++ {
+		sorted := slices.Clone(hands)
+		sort.Slice(sorted, func(i, j int) bool { return lessByType(sorted[i], sorted[j], jokerRuleset) })
+	}
+}
+
+func BenchmarkTotalScore(b *testing.B) {
+	hands := randomHands(benchmarkHands, jokerRuleset)
+	for i := 0; i < b.N; i++ {
+		totalScore(slices.Clone(hands))
+	}
+}
+
+func TestParseHandWhitespace(t *testing.T) {
+	for _, line := range []string{"KK677 28", "KK677\t28", "  KK677   28  "} {
+		h, err := parseHand(line, jackRuleset)
+		if err != nil {
+			t.Errorf("parseHand(%q) failed: %v", line, err)
+			continue
+		}
+		if got := jackRuleset.symbols(h.cards); got != "KK677" || h.bid != 28 {
+			t.Errorf("parseHand(%q) = %s %d, want KK677 28", line, got, h.bid)
+		}
+	}
+}
+
+func TestParseHandErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		col  int
+	}{
+		{"KKX77 3", 3},
+		{"KK77 3", 1},
+		{"KK777 -3", 7},
+		{"KK777 3 x", 9},
+		{"KK777", 6},
+		{"KK777\tx3", 7},
+	}
+	for _, tt := range tests {
+		_, err := parseHand(tt.line, jackRuleset)
+		var parseErr *parse.Error
+		if !errors.As(err, &parseErr) || parseErr.Col != tt.col {
+			t.Errorf("parseHand(%q) = %v, want error at column %d", tt.line, err, tt.col)
+		}
+	}
+}
+
+func TestPokerTotalScore(t *testing.T) {
+	// From the weakest to the strongest.
+	lines := []string{
+		"2H3D5S9CKD 10",
+		"KC KD 2S 2C 3D 7",
+		"KC KD 2S 2C 4D 8",
+		"2C 3H 4S 5C AH 100",
+		"KH KD KS 2C 2D 5",
+		"2D 3D 4D 5D 6D 1000",
+		"AS KS QS JS TS 1",
+	}
+	hands := make([]hand, len(lines))
+	want := 0
+	for i, line := range lines {
+		var err error
+		if hands[i], err = parsePokerHand(line); err != nil {
+			t.Fatal(err)
+		}
+		want += (i + 1) * hands[i].bid
+	}
+	slices.Reverse(hands)
+	if got := totalScore(hands); got != want {
+		t.Errorf("totalScore() = %d, want %d", got, want)
+	}
+}
+
+func TestFindTiedHands(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		parse func(line string) (hand, error)
+		want  [][]int
+	}{
+		{
+			name:  "camel",
+			input: "KK677 1\nK6K77 2\nKK677 3\n",
+			parse: func(line string) (hand, error) { return parseHand(line, jackRuleset) },
+			want:  [][]int{{1, 3}},
+		},
+		{
+			name:  "poker hands of equal strength",
+			input: "AS AH KS KH 2C 1\nAD AC KD KC 2S 2\nAD AC KD KC 3S 3\n",
+			parse: parsePokerHand,
+			want:  [][]int{{1, 2}},
+		},
+	}
+	for _, tt := range tests {
+		hands, err := loadHands(strings.NewReader(tt.input), tt.parse)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := findTiedHands(hands); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: findTiedHands() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Wild cards must make the strongest hand under the ruleset's own
+// category order, not just join the most common card.
+func TestWildCardsWithCustomCategories(t *testing.T) {
+	categories, err := parseHandCategories("high: 1; pair: 2; three: 3; two pair: 2 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := newRuleset("J23456789TQKA", "J", 4, categories)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		cards    string
+		wantType string
+		wildAs   string
+	}{
+		{"AAKJ", "two pair", "K"},
+		// Four of a kind is no category here.
+		{"AAAJ", "three", "K"},
+		{"AKJJ", "two pair", "AK"},
+		{"JJJJ", "two pair", "AAKK"},
+		{"AK23", "high", ""},
+	}
+	for _, tt := range tests {
+		h, err := parseHand(tt.cards+" 1", rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		handType, substitutes := h.bestType(rules)
+		if got, wildAs := rules.typeName(handType), rules.symbols(substitutes); got != tt.wantType || wildAs != tt.wildAs {
+			t.Errorf("bestType(%s) = %s with wild cards as %q, want %s with %q", tt.cards, got, wildAs, tt.wantType, tt.wildAs)
+		}
+		explanation := explainHands([]hand{h}, rules)[0]
+		if explanation.Type != tt.wantType || explanation.WildAs != tt.wildAs {
+			t.Errorf("explainHands(%s) = %+v, want %s with wild cards as %q", tt.cards, explanation, tt.wantType, tt.wildAs)
+		}
+	}
+}
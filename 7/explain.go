@@ -0,0 +1,79 @@
+package day7
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Why a hand ranks where it does.
+type handExplanation struct {
+	Rank  int    `json:"rank"`
+	Cards string `json:"cards"`
+	// Hand type when wild cards count as themselves.
+	BaseType string `json:"base_type"`
+	Type     string `json:"type"`
+	Unknown  bool   `json:"unknown,omitempty"`
+	// Cards the wild cards act as, one per wild card, see hand.bestType.
+	// Empty if the hand has none.
+	WildAs   string `json:"wild_as,omitempty"`
+	Bid      int    `json:"bid"`
+	Winnings int    `json:"winnings"`
+}
+
+func countUnknownHands(hands []hand, rules ruleset) int {
+	unknown := 0
+	for _, h := range hands {
+		if h.Type(rules) == UnknownHand {
+			unknown++
+		}
+	}
+	return unknown
+}
+
+// Explains `hands`, which must be sorted by totalScore.
+func explainHands(hands []hand, rules ruleset) []handExplanation {
+	baseRules := rules.withoutWildcards()
+	explanations := make([]handExplanation, len(hands))
+	for i, h := range hands {
+		e := &explanations[i]
+		e.Rank = i + 1
+		e.Cards = rules.symbols(h.cards)
+		e.BaseType = baseRules.typeName(h.Type(baseRules))
+		handType, substitutes := h.bestType(rules)
+		e.Type = rules.typeName(handType)
+		e.Unknown = handType == UnknownHand
+		e.WildAs = rules.symbols(substitutes)
+		e.Bid = h.bid
+		e.Winnings = e.Rank * h.bid
+	}
+	return explanations
+}
+
+func writeExplanationTable(w io.Writer, explanations []handExplanation, score int) {
+	fmt.Fprintf(w, "%6s  %-8s %-16s %-16s %-4s %8s %10s\n",
+		"rank", "cards", "base type", "type", "wild", "bid", "winnings")
+	for _, e := range explanations {
+		wildAs := "-"
+		if e.WildAs != "" {
+			wildAs = e.WildAs
+		}
+		flag := ""
+		if e.Unknown {
+			flag = "  <- matches no hand category"
+		}
+		fmt.Fprintf(w, "%6d  %-8s %-16s %-16s %-4s %8d %10d%s\n",
+			e.Rank, e.Cards, e.BaseType, e.Type, wildAs, e.Bid, e.Winnings, flag)
+	}
+	fmt.Fprintf(w, "total winnings: %d\n", score)
+}
+
+func writeExplanationJSON(w io.Writer, explanations []handExplanation, score int) error {
+	output := struct {
+		Hands         []handExplanation `json:"hands"`
+		TotalWinnings int               `json:"total_winnings"`
+	}{explanations, score}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
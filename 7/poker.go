@@ -0,0 +1,185 @@
+package day7
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/dinord/aoc23/parse"
+)
+
+// Classic poker hand types, from the weakest to the strongest.
+const (
+	pokerHighCard handType = iota + 1
+	pokerOnePair
+	pokerTwoPair
+	pokerThreeOfAKind
+	pokerStraight
+	pokerFlush
+	pokerFullHouse
+	pokerFourOfAKind
+	pokerStraightFlush
+)
+
+const pokerHandSize = 5
+
+var pokerRanks = map[rune]int{
+	'2': 2,
+	'3': 3,
+	'4': 4,
+	'5': 5,
+	'6': 6,
+	'7': 7,
+	'8': 8,
+	'9': 9,
+	'T': 10,
+	'J': 11,
+	'Q': 12,
+	'K': 13,
+	'A': 14,
+}
+
+var pokerSuits = map[rune]int{
+	'C': 0,
+	'D': 1,
+	'H': 2,
+	'S': 3,
+}
+
+// Classifies a poker hand, and returns the ranks that break ties
+// between hands of the same type, from the most important one.
+func pokerType(ranks []int, suits []int) (t handType, tieBreakers []int) {
+	rankCounts := make(map[int]int)
+	for _, r := range ranks {
+		rankCounts[r]++
+	}
+	// Ranks ordered by how often they appear, then by rank.
+	tieBreakers = make([]int, 0, len(rankCounts))
+	for r := range rankCounts {
+		tieBreakers = append(tieBreakers, r)
+	}
+	slices.SortFunc(tieBreakers, func(a, b int) int {
+		if c := cmp.Compare(rankCounts[b], rankCounts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(b, a)
+	})
+
+	flush := true
+	for _, s := range suits {
+		flush = flush && s == suits[0]
+	}
+	straight := false
+	if len(tieBreakers) == pokerHandSize {
+		if tieBreakers[0]-tieBreakers[pokerHandSize-1] == pokerHandSize-1 {
+			straight = true
+			tieBreakers = tieBreakers[:1]
+		} else if slices.Equal(tieBreakers, []int{14, 5, 4, 3, 2}) {
+			// The Ace plays low in A-2-3-4-5, the weakest straight.
+			straight = true
+			tieBreakers = []int{5}
+		}
+	}
+
+	topCount := rankCounts[tieBreakers[0]]
+	switch {
+	case straight && flush:
+		return pokerStraightFlush, tieBreakers
+	case topCount == 4:
+		return pokerFourOfAKind, tieBreakers
+	case topCount == 3 && len(tieBreakers) == 2:
+		return pokerFullHouse, tieBreakers
+	case flush:
+		return pokerFlush, tieBreakers
+	case straight:
+		return pokerStraight, tieBreakers
+	case topCount == 3:
+		return pokerThreeOfAKind, tieBreakers
+	case topCount == 2 && len(tieBreakers) == 3:
+		return pokerTwoPair, tieBreakers
+	case topCount == 2:
+		return pokerOnePair, tieBreakers
+	}
+	return pokerHighCard, tieBreakers
+}
+
+// Packs the hand type and the tie breakers into a single integer,
+// so that stronger hands have larger keys.
+func pokerStrengthKey(t handType, tieBreakers []int) uint64 {
+	const RankBits = 4
+	key := uint64(t)
+	for i := 0; i < pokerHandSize; i++ {
+		key <<= RankBits
+		if i < len(tieBreakers) {
+			key |= uint64(tieBreakers[i])
+		}
+	}
+	return key
+}
+
+// Parses a hand of five cards, each a rank followed by a suit,
+// and a bid, e.g. `AS KS QH 9D 2C 765` or `ASKSQH9D2C 765`.
+func parsePokerHand(s string) (h hand, err error) {
+	fields := parse.Field{Text: s, Col: 1}.Fields()
+	if len(fields) < 2 {
+		return h, &parse.Error{Col: 1, Err: fmt.Errorf("Expected %d cards and a bid, got: %s", pokerHandSize, s)}
+	}
+
+	ranks := make([]int, 0, pokerHandSize)
+	suits := make([]int, 0, pokerHandSize)
+	seen := make(map[string]bool)
+	bidIndex := -1
+	for i, f := range fields {
+		if len(ranks) == pokerHandSize {
+			bidIndex = i
+			break
+		}
+		text := []rune(f.Text)
+		if len(text)%2 != 0 {
+			return h, &parse.Error{Col: f.Col, Err: fmt.Errorf("Expected cards like `AS`, got: %s", f.Text)}
+		}
+		for j := 0; j < len(text); j += 2 {
+			col := f.Col + j
+			if len(ranks) == pokerHandSize {
+				return h, &parse.Error{Col: col, Err: fmt.Errorf("Expected %d cards", pokerHandSize)}
+			}
+			rank, ok := pokerRanks[text[j]]
+			if !ok {
+				return h, &parse.Error{Col: col, Err: fmt.Errorf("Not a card rank: %c", text[j])}
+			}
+			suit, ok := pokerSuits[text[j+1]]
+			if !ok {
+				return h, &parse.Error{Col: col + 1, Err: fmt.Errorf("Not a card suit: %c", text[j+1])}
+			}
+			card := string(text[j : j+2])
+			if seen[card] {
+				return h, &parse.Error{Col: col, Err: fmt.Errorf("Card appears twice: %s", card)}
+			}
+			seen[card] = true
+			ranks = append(ranks, rank)
+			suits = append(suits, suit)
+		}
+	}
+	if bidIndex == -1 {
+		return h, &parse.Error{Col: utf8.RuneCountInString(s) + 1, Err: errors.New("Expected a bid after the cards")}
+	}
+	if len(fields) > bidIndex+1 {
+		extra := fields[bidIndex+1]
+		return h, &parse.Error{Col: extra.Col, Err: fmt.Errorf("Unexpected field after the bid: %s", extra.Text)}
+	}
+
+	h.cards = ranks
+	h.key = pokerStrengthKey(pokerType(ranks, suits))
+	bid := fields[bidIndex]
+	h.bid, err = strconv.Atoi(bid.Text)
+	if err != nil {
+		return h, &parse.Error{Col: bid.Col, Err: fmt.Errorf("Expected an integer bid, got: %s", bid.Text)}
+	}
+	if h.bid < 0 {
+		return h, &parse.Error{Col: bid.Col, Err: fmt.Errorf("Expected a non-negative bid, got: %d", h.bid)}
+	}
+	return h, nil
+}
@@ -0,0 +1,224 @@
+package day7
+
+import (
+	"fmt"
+	"math/bits"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dinord/aoc23/parse"
+)
+
+// A kind of hand, e.g. a full house, identified by how many times
+// its most common cards appear.
+type handCategory struct {
+	name string
+	// In decreasing order. A hand matches if its own card counts,
+	// sorted in decreasing order, start with these.
+	counts []int
+}
+
+// How cards are read from the input and how hands are classified.
+type ruleset struct {
+	// From 1 for the weakest card.
+	cardRanks map[rune]int
+	// Card symbols by rank, starting from the weakest card at index 0.
+	cardSymbols []rune
+	// Ranks of cards that act like whatever card makes the strongest hand.
+	wildRanks map[int]bool
+	handSize  int
+	// From the weakest to the strongest. A hand has the type of
+	// the strongest category it matches.
+	categories []handCategory
+	// Bits per card in a hand's strength key.
+	cardBits int
+}
+
+var camelCardCategories = []handCategory{
+	{name: "high card", counts: []int{1, 1, 1, 1, 1}},
+	{name: "one pair", counts: []int{2, 1, 1, 1}},
+	{name: "two pair", counts: []int{2, 2, 1}},
+	{name: "three of a kind", counts: []int{3, 1, 1}},
+	{name: "full house", counts: []int{3, 2}},
+	{name: "four of a kind", counts: []int{4, 1}},
+	{name: "five of a kind", counts: []int{5}},
+}
+
+// Part one: J is a Jack, ranked between T and Q.
+var jackRuleset = mustRuleset(newRuleset("23456789TJQKA", "", 5, camelCardCategories))
+
+// Part two: J is a Joker, the weakest card, which acts like
+// whatever card makes the strongest hand type.
+var jokerRuleset = mustRuleset(newRuleset("J23456789TQKA", "J", 5, camelCardCategories))
+
+// Builds a ruleset from the cards ordered from weakest to strongest,
+// the wild cards, the number of cards per hand and the hand categories
+// ordered from weakest to strongest.
+func newRuleset(cards string, wild string, handSize int, categories []handCategory) (r ruleset, err error) {
+	r.cardRanks = make(map[rune]int)
+	for _, c := range cards {
+		if _, ok := r.cardRanks[c]; ok {
+			return r, fmt.Errorf("Card %c appears twice in: %s", c, cards)
+		}
+		r.cardSymbols = append(r.cardSymbols, c)
+		r.cardRanks[c] = len(r.cardSymbols)
+	}
+
+	r.wildRanks = make(map[int]bool)
+	for _, c := range wild {
+		rank, ok := r.cardRanks[c]
+		if !ok {
+			return r, fmt.Errorf("Wild card %c is not one of: %s", c, cards)
+		}
+		r.wildRanks[rank] = true
+	}
+
+	if handSize <= 0 {
+		return r, fmt.Errorf("Expected positive hand size, got: %d", handSize)
+	}
+	r.handSize = handSize
+
+	if len(categories) == 0 {
+		return r, fmt.Errorf("Expected at least one hand category")
+	}
+	for _, category := range categories {
+		sum := 0
+		for _, c := range category.counts {
+			sum += c
+		}
+		if sum > handSize || len(category.counts) == 0 || category.counts[len(category.counts)-1] <= 0 ||
+			!slices.IsSortedFunc(category.counts, func(a, b int) int { return b - a }) {
+			return r, fmt.Errorf("Expected decreasing positive counts of at most %d cards in category %s, got: %v",
+				handSize, category.name, category.counts)
+		}
+	}
+	r.categories = categories
+
+	r.cardBits = bits.Len(uint(len(r.cardRanks)))
+	if keyBits := bits.Len(uint(len(categories))) + handSize*r.cardBits; keyBits > 64 {
+		return r, fmt.Errorf("Hands of %d out of %d cards need %d bits, at most 64 are supported",
+			handSize, len(r.cardRanks), keyBits)
+	}
+	return r, nil
+}
+
+// Returns the names of the given card ranks.
+func (r ruleset) symbols(cards []int) string {
+	symbols := make([]rune, len(cards))
+	for i, card := range cards {
+		symbols[i] = r.cardSymbols[card-1]
+	}
+	return string(symbols)
+}
+
+// Returns the same ruleset, but without any wild cards.
+func (r ruleset) withoutWildcards() ruleset {
+	r.wildRanks = map[int]bool{}
+	return r
+}
+
+// Returns the type of a hand with the given number of each card,
+// which is the strongest category its counts match.
+func (r ruleset) classify(cardCounts map[int]int) handType {
+	counts := make([]int, 0, len(cardCounts))
+	for _, c := range cardCounts {
+		counts = append(counts, c)
+	}
+	slices.SortFunc(counts, func(a, b int) int { return b - a })
+	for i := len(r.categories) - 1; i >= 0; i-- {
+		categoryCounts := r.categories[i].counts
+		if len(counts) >= len(categoryCounts) && slices.Equal(counts[:len(categoryCounts)], categoryCounts) {
+			return handType(i + 1)
+		}
+	}
+	return UnknownHand
+}
+
+// Returns the name of the hand type, or UNKNOWN if it matches no category.
+func (r ruleset) typeName(t handType) string {
+	if t == UnknownHand {
+		return "UNKNOWN"
+	}
+	return r.categories[t-1].name
+}
+
+func mustRuleset(r ruleset, err error) ruleset {
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Parses a category like `full house: 3 2`.
+func parseHandCategory(s string) (category handCategory, err error) {
+	name, countsText, found := strings.Cut(s, ":")
+	if !found {
+		return category, fmt.Errorf("Expected `<name>: <counts>`, got: %s", s)
+	}
+	category.name = strings.TrimSpace(name)
+	for _, field := range strings.Fields(countsText) {
+		count, err := strconv.Atoi(field)
+		if err != nil {
+			return category, err
+		}
+		category.counts = append(category.counts, count)
+	}
+	return category, nil
+}
+
+// Parses categories like `high card: 1 1 1 1 1; one pair: 2 1 1 1`.
+func parseHandCategories(s string) ([]handCategory, error) {
+	categories := make([]handCategory, 0, 10) // arbitrary capacity
+	for _, part := range strings.Split(s, ";") {
+		category, err := parseHandCategory(part)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+// Loads a ruleset from a file of parse.KeyValueLines, like:
+//
+//	cards: 23456789TJQKA
+//	wild: J
+//	hand_size: 5
+//	category: high card: 1 1 1 1 1
+//	category: one pair: 2 1 1 1
+//
+// Categories are listed from the weakest to the strongest.
+func loadRuleset(inputPath string) (r ruleset, err error) {
+	inputFile, err := os.Open(inputPath)
+	if err != nil {
+		return r, err
+	}
+	defer inputFile.Close()
+
+	var cards, wild string
+	handSize := 0
+	categories := make([]handCategory, 0, 10) // arbitrary capacity
+	err = parse.KeyValueLines(inputFile, func(key parse.Field, value parse.Field) (err error) {
+		switch key.Text {
+		case "cards":
+			cards = value.Text
+		case "wild":
+			wild = value.Text
+		case "hand_size":
+			handSize, err = value.Int()
+		case "category":
+			var category handCategory
+			category, err = parseHandCategory(value.Text)
+			categories = append(categories, category)
+		default:
+			err = key.Errorf("Unknown key: %s", key.Text)
+		}
+		return err
+	})
+	if err != nil {
+		return r, err
+	}
+	return newRuleset(cards, wild, handSize, categories)
+}
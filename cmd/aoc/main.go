@@ -0,0 +1,369 @@
+// Command aoc runs the Advent of Code 2023 puzzle solvers.
+//
+// Usage:
+//
+//	aoc run --day 5 --part 2 --input input.txt
+//	generate_input | aoc run --day 5
+//	aoc run --all --input_dir inputs/2023
+//	aoc day 5 --input input.txt --trace text
+//	aoc examples --day 5 --page day5.html
+//	aoc verify --input_dir inputs/2023 --answers_dir answers
+//	aoc fetch --day 5
+//
+// Without --input, or with --input -, the input is read from standard
+// input. Inputs ending in .gz or .zst are decompressed, see solver.Open.
+// With --all, the input of day N is read from `<input_dir>/N.txt`.
+// The day command runs a day's own command line, with its extra flags,
+// on the input and part given like for run, except that the part
+// defaults to 2.
+// The examples command saves the examples of a saved puzzle page as test
+// fixtures, see package examples. The verify command solves every input
+// again and compares the answers with the known ones, see package known.
+// The fetch command downloads an input into inputs/2023, or the cache
+// directory of its config, see package fetch.
+//
+// Exit codes tell apart kinds of failures:
+//
+//	1: the puzzle input is malformed or has no answer
+//	2: bad flags or arguments
+//	3: reading the input failed
+//	4: internal error, i.e. a bug in a solver
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	_ "github.com/dinord/aoc23/days"
+	"github.com/dinord/aoc23/examples"
+	"github.com/dinord/aoc23/fetch"
+	"github.com/dinord/aoc23/known"
+	"github.com/dinord/aoc23/solver"
+)
+
+// The year of the puzzles.
+const year = 2023
+
+// Where fetch caches the inputs, see fetch.Client.CachePath.
+var defaultInputDir = filepath.Join(fetch.DefaultConfig.CacheDir, strconv.Itoa(year))
+
+var exitCodes = map[solver.ErrorKind]int{
+	solver.InputError:    1,
+	solver.UsageError:    2, // like the flag package
+	solver.IOError:       3,
+	solver.InternalError: 4,
+}
+
+const inputUsage = "Path to puzzle input file, or `-` for standard input"
+
+const usage = `Usage:
+  aoc run --day <day> [--part <part>] [--input <path>]
+  aoc run --all [--input_dir <dir>]
+  aoc day <day> [--part <part>] [--input <path>] [day flags]
+  aoc examples --day <day> --page <path> [--dir <dir>]
+  aoc verify [--input_dir <dir>] [--answers_dir <dir>] [--update]
+  aoc fetch --day <day> [--config <path>]`
+
+// Finds the input of day `number` in `dir`, which may be compressed.
+// Returns the uncompressed path if there is none.
+func findInput(dir string, number int) (path string, ok bool) {
+	path = filepath.Join(dir, strconv.Itoa(number)+".txt")
+	for _, ext := range []string{"", ".gz", ".zst"} {
+		if _, err := os.Stat(path + ext); !errors.Is(err, fs.ErrNotExist) {
+			return path + ext, true
+		}
+	}
+	return path, false
+}
+
+// Copies standard input to a temporary file, and returns its path.
+func copyStdin() (path string, err error) {
+	file, err := os.CreateTemp("", "aoc-input-*.txt")
+	if err != nil {
+		return "", &solver.Error{Kind: solver.IOError, Err: err}
+	}
+	_, err = io.Copy(file, os.Stdin)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", &solver.Error{Kind: solver.IOError, Err: err}
+	}
+	return file.Name(), nil
+}
+
+// Prints the answer to a single part, or to both parts if `part` is zero.
+func runDay(day solver.Day, part int, inputPath string) error {
+	parts := []int{part}
+	if part == 0 {
+		parts = []int{1, 2}
+	}
+	if solver.IsStdin(inputPath) && len(parts) > 1 {
+		// Standard input can only be read once, so copy it to a file for
+		// both parts. Inputs may be too large to keep in memory.
+		copyPath, err := copyStdin()
+		if err != nil {
+			return err
+		}
+		defer os.Remove(copyPath)
+		inputPath = copyPath
+	}
+	for _, p := range parts {
+		answer, err := solver.SolveFile(day.Solver, p, inputPath)
+		if err != nil {
+			return fmt.Errorf("Day %d part %d: %w", day.Number, p, err)
+		}
+		fmt.Printf("Day %d part %d: %s\n", day.Number, p, answer)
+	}
+	return nil
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	dayFlag := flags.Int("day", 0, "Day of the puzzle to solve")
+	partFlag := flags.Int("part", 0, "Part of the puzzle to solve, or 0 for both")
+	inputFlag := flags.String("input", "", inputUsage)
+	allFlag := flags.Bool("all", false, "Solve every part of every day")
+	inputDirFlag := flags.String("input_dir", defaultInputDir, "Directory with the input of day N in N.txt, N.txt.gz or N.txt.zst, for --all")
+	flags.Parse(args)
+
+	if *partFlag < 0 || *partFlag > 2 {
+		return solver.Errorf(solver.UsageError, "Flag --part must be 0, 1 or 2, got: %d", *partFlag)
+	}
+	if *allFlag {
+		for _, day := range solver.Days() {
+			inputPath, ok := findInput(*inputDirFlag, day.Number)
+			if !ok {
+				log.Printf("Skipping day %d, no input at: %s", day.Number, inputPath)
+				continue
+			}
+			if err := runDay(day, *partFlag, inputPath); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	day, ok := solver.Lookup(*dayFlag)
+	if !ok {
+		return solver.Errorf(solver.UsageError, "No solver for day %d", *dayFlag)
+	}
+	return runDay(day, *partFlag, *inputFlag)
+}
+
+// Runs a day's own command line. A panicking day fails with an
+// InternalError, like in solver.Solve.
+func runDayMain(args []string) (err error) {
+	if len(args) == 0 {
+		return solver.Errorf(solver.UsageError, "%s", usage)
+	}
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		return solver.Errorf(solver.UsageError, "Expected a day number, got: %s", args[0])
+	}
+	day, ok := solver.Lookup(number)
+	if !ok {
+		return solver.Errorf(solver.UsageError, "No solver for day %d", number)
+	}
+	if day.Command == nil {
+		return solver.Errorf(solver.UsageError, "Day %d has no flags of its own, use `aoc run --day %d`", number, number)
+	}
+
+	flags := flag.NewFlagSet(fmt.Sprintf("day %d", number), flag.ExitOnError)
+	inputFlag := flags.String("input", "", inputUsage)
+	partFlag := flags.Int("part", 2, "Part of the puzzle to solve, 1 or 2. Unlike for aoc run, there is no 0 for both parts")
+	command := day.Command(flags)
+	flags.Parse(args[1:])
+
+	if *partFlag != 1 && *partFlag != 2 {
+		return solver.Errorf(solver.UsageError, "Flag --part must be 1 or 2, got: %d", *partFlag)
+	}
+	input, err := solver.Open(*inputFlag)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+	defer func() {
+		if r := recover(); r != nil {
+			err = solver.Errorf(solver.InternalError, "Day %d panicked: %v\n%s", number, r, debug.Stack())
+		}
+	}()
+	return command(input, *partFlag)
+}
+
+func hashInputFile(inputPath string) (string, error) {
+	inputFile, err := solver.Open(inputPath)
+	if err != nil {
+		return "", err
+	}
+	defer inputFile.Close()
+	hash, err := known.HashInput(inputFile)
+	if err != nil {
+		return "", &solver.Error{Kind: solver.IOError, Err: err}
+	}
+	return hash, nil
+}
+
+// Checks the answers of one day against the known answers, recording new
+// answers, and changed answers too if `update` is set.
+// Returns how many answers changed or failed.
+func verifyDay(day solver.Day, inputPath string, answersDir string, update bool) (mismatches int, err error) {
+	hash, err := hashInputFile(inputPath)
+	if err != nil {
+		return 0, err
+	}
+	answers, err := known.Load(answersDir, day.Number)
+	if err != nil {
+		return 0, err
+	}
+
+	dirty := false
+	for part := 1; part <= 2; part++ {
+		key := known.Key{Part: part, InputHash: hash}
+		answer, err := solver.SolveFile(day.Solver, part, inputPath)
+		if err != nil {
+			fmt.Printf("Day %d part %d: FAILED: %v\n", day.Number, part, err)
+			mismatches++
+			continue
+		}
+		want, ok := answers[key]
+		switch {
+		case !ok:
+			fmt.Printf("Day %d part %d: %s (new)\n", day.Number, part, answer)
+			answers[key] = answer
+			dirty = true
+		case answer == want:
+			fmt.Printf("Day %d part %d: %s (ok)\n", day.Number, part, answer)
+		default:
+			fmt.Printf("Day %d part %d: %s (CHANGED, was %s)\n", day.Number, part, answer, want)
+			mismatches++
+			if update {
+				answers[key] = answer
+				dirty = true
+			}
+		}
+	}
+	if dirty {
+		if err := answers.Save(answersDir, day.Number); err != nil {
+			return mismatches, err
+		}
+	}
+	return mismatches, nil
+}
+
+func verify(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	inputDirFlag := flags.String("input_dir", defaultInputDir, "Directory with the input of day N in N.txt, N.txt.gz or N.txt.zst")
+	answersDirFlag := flags.String("answers_dir", "answers", "Directory with the known answers of day N in N.txt")
+	updateFlag := flags.Bool("update", false, "Record changed answers instead of failing")
+	flags.Parse(args)
+
+	mismatches := 0
+	for _, day := range solver.Days() {
+		inputPath, ok := findInput(*inputDirFlag, day.Number)
+		if !ok {
+			log.Printf("Skipping day %d, no input at: %s", day.Number, inputPath)
+			continue
+		}
+		n, err := verifyDay(day, inputPath, *answersDirFlag, *updateFlag)
+		if err != nil {
+			return fmt.Errorf("Day %d: %w", day.Number, err)
+		}
+		mismatches += n
+	}
+	if mismatches > 0 && !*updateFlag {
+		return solver.Errorf(solver.InternalError, "%d answers changed or failed, see above", mismatches)
+	}
+	return nil
+}
+
+func fetchInput(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	dayFlag := flags.Int("day", 0, "Day of the puzzle input to download")
+	configFlag := flags.String("config", fetch.DefaultConfigPath(), "Path to the config file with the session token")
+	flags.Parse(args)
+
+	config, err := fetch.LoadConfig(*configFlag)
+	if errors.Is(err, fs.ErrNotExist) {
+		return solver.Errorf(solver.UsageError, "No config at %s, create it with a line `session: <token>`", *configFlag)
+	}
+	if err != nil {
+		return err
+	}
+	path, err := fetch.NewClient(config).Input(year, *dayFlag)
+	if err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
+
+func extractExamples(args []string) error {
+	flags := flag.NewFlagSet("examples", flag.ExitOnError)
+	dayFlag := flags.Int("day", 0, "Day of the puzzle page")
+	pageFlag := flags.String("page", "", "Path to the saved puzzle page")
+	dirFlag := flags.String("dir", "examples/testdata", "Directory to write the fixtures to")
+	flags.Parse(args)
+
+	if *dayFlag <= 0 {
+		return solver.Errorf(solver.UsageError, "Flag --day must be positive, got: %d", *dayFlag)
+	}
+	if *pageFlag == "" {
+		return solver.Errorf(solver.UsageError, "Flag --page must be non-empty!")
+	}
+	page, err := os.Open(*pageFlag)
+	if err != nil {
+		return err
+	}
+	defer page.Close()
+	extracted, err := examples.Extract(page)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *pageFlag, err)
+	}
+	if err := examples.WriteFixtures(*dirFlag, *dayFlag, extracted); err != nil {
+		return err
+	}
+	for _, e := range extracted {
+		fmt.Printf("Day %d part %d: %d input lines, answer %s\n",
+			*dayFlag, e.Part, strings.Count(e.Input, "\n"), e.Answer)
+	}
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Print(usage)
+		os.Exit(exitCodes[solver.UsageError])
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = run(os.Args[2:])
+	case "day":
+		err = runDayMain(os.Args[2:])
+	case "examples":
+		err = extractExamples(os.Args[2:])
+	case "verify":
+		err = verify(os.Args[2:])
+	case "fetch":
+		err = fetchInput(os.Args[2:])
+	default:
+		err = solver.Errorf(solver.UsageError, "Unknown command: %s\n%s", os.Args[1], usage)
+	}
+	if err != nil {
+		kind := solver.KindOf(err)
+		log.Printf("%v: %v", kind, err)
+		os.Exit(exitCodes[kind])
+	}
+}
@@ -0,0 +1,15 @@
+// Package days registers the solvers of every day with package solver.
+// Import it for its side effects:
+//
+//	import _ "github.com/dinord/aoc23/days"
+package days
+
+import (
+	_ "github.com/dinord/aoc23/1"
+	_ "github.com/dinord/aoc23/2"
+	_ "github.com/dinord/aoc23/3"
+	_ "github.com/dinord/aoc23/4"
+	_ "github.com/dinord/aoc23/5"
+	_ "github.com/dinord/aoc23/6"
+	_ "github.com/dinord/aoc23/7"
+)
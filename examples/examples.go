@@ -0,0 +1,128 @@
+// Package examples extracts the examples from saved puzzle pages, and
+// stores them as fixtures that every day's solver is tested against.
+//
+// The fixtures of day N are kept in `<dir>/N/`, one pair of files per part:
+// `partP.txt` holds the example input, and `partP.answer` the answer.
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dinord/aoc23/solver"
+)
+
+// An example input from a puzzle page, and its answer to one part.
+type Example struct {
+	Part   int
+	Input  string
+	Answer solver.Answer
+}
+
+var (
+	// Each part of the puzzle is described in its own article.
+	articleRegexp = regexp.MustCompile(`(?s)<article class="day-desc">(.*?)</article>`)
+	// Example inputs are preformatted blocks.
+	inputRegexp = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	// Answers are highlighted code, e.g. `<code><em>142</em></code>`.
+	answerRegexp = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>`)
+	tagRegexp    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Returns the text of an HTML fragment, without tags.
+func text(fragment string) string {
+	return html.UnescapeString(tagRegexp.ReplaceAllString(fragment, ""))
+}
+
+// Extracts one example per puzzle part from a saved puzzle page.
+//
+// The example of a part is the last preformatted block in its
+// description, or the example of the previous part if there is none.
+// The answer is the last highlighted code, which is how puzzle pages
+// conclude the example.
+func Extract(page io.Reader) ([]Example, error) {
+	data, err := io.ReadAll(page)
+	if err != nil {
+		return nil, err
+	}
+	articles := articleRegexp.FindAllStringSubmatch(string(data), -1)
+	if len(articles) == 0 {
+		return nil, errors.New("Expected puzzle descriptions in `<article class=\"day-desc\">`, found none")
+	}
+
+	examples := make([]Example, 0, len(articles))
+	input := ""
+	for i, article := range articles {
+		part := i + 1
+		if inputs := inputRegexp.FindAllStringSubmatch(article[1], -1); len(inputs) > 0 {
+			input = text(inputs[len(inputs)-1][1])
+		}
+		if input == "" {
+			return nil, fmt.Errorf("Expected an example in `<pre><code>` in part %d, found none", part)
+		}
+		answers := answerRegexp.FindAllStringSubmatch(article[1], -1)
+		if len(answers) == 0 {
+			return nil, fmt.Errorf("Expected an answer in `<code><em>` in part %d, found none", part)
+		}
+		answer := strings.TrimSpace(text(answers[len(answers)-1][1]))
+		examples = append(examples, Example{Part: part, Input: input, Answer: solver.Answer(answer)})
+	}
+	return examples, nil
+}
+
+func inputPath(dir string, day int, part int) string {
+	return filepath.Join(dir, strconv.Itoa(day), fmt.Sprintf("part%d.txt", part))
+}
+
+func answerPath(dir string, day int, part int) string {
+	return filepath.Join(dir, strconv.Itoa(day), fmt.Sprintf("part%d.answer", part))
+}
+
+// Writes the examples of `day` as fixtures under `dir`,
+// replacing any previous fixtures of the same parts.
+func WriteFixtures(dir string, day int, examples []Example) error {
+	if err := os.MkdirAll(filepath.Join(dir, strconv.Itoa(day)), 0o755); err != nil {
+		return err
+	}
+	for _, e := range examples {
+		if err := os.WriteFile(inputPath(dir, day, e.Part), []byte(e.Input), 0o644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(answerPath(dir, day, e.Part), []byte(string(e.Answer)+"\n"), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Loads the fixtures of `day` from `dir`, if any.
+func LoadFixtures(dir string, day int) ([]Example, error) {
+	examples := make([]Example, 0, 2)
+	for part := 1; part <= 2; part++ {
+		answer, err := os.ReadFile(answerPath(dir, day, part))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		input, err := os.ReadFile(inputPath(dir, day, part))
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, Example{
+			Part:   part,
+			Input:  string(input),
+			Answer: solver.Answer(strings.TrimSpace(string(answer))),
+		})
+	}
+	return examples, nil
+}
@@ -0,0 +1,101 @@
+package examples_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	_ "github.com/dinord/aoc23/days"
+	"github.com/dinord/aoc23/examples"
+	"github.com/dinord/aoc23/solver"
+)
+
+// Runs every day's solver against the examples in testdata.
+func TestSolversMatchExamples(t *testing.T) {
+	for _, day := range solver.Days() {
+		fixtures, err := examples.LoadFixtures("testdata", day.Number)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(fixtures) == 0 {
+			t.Errorf("Day %d has no examples, add them with `aoc examples`", day.Number)
+		}
+		for _, e := range fixtures {
+			t.Run(fmt.Sprintf("day%d/part%d", day.Number, e.Part), func(t *testing.T) {
+				got, err := solver.Solve(day.Solver, e.Part, strings.NewReader(e.Input))
+				if err != nil || got != e.Answer {
+					t.Errorf("Got %q, %v, want %q", got, err, e.Answer)
+				}
+			})
+		}
+	}
+}
+
+const page = `<!DOCTYPE html>
+<html><body><main>
+<article class="day-desc"><h2>--- Day 1: Trebuchet?! ---</h2>
+<p>For example:</p>
+<pre><code>1abc2
+pqr3stu<em>8</em>vwx
+</code></pre>
+<p>Adding these together produces <code><em>38</em></code>.</p>
+</article>
+<p>Your puzzle answer was <code>54644</code>.</p>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2>
+<p>Spelled out digits like <code>one</code> count as well, e.g. <code>a &lt; b</code>:</p>
+<pre><code>two1nine
+</code></pre>
+<pre><code>eightwothree
+</code></pre>
+<p>The answer is <code><em>83</em></code>.</p>
+</article>
+<article class="day-desc"><h2>--- Part Three ---</h2>
+<p>Using the same example, the answer is <code><em>-1</em></code>.</p>
+</article>
+</main></body></html>
+`
+
+func TestExtract(t *testing.T) {
+	got, err := examples.Extract(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []examples.Example{
+		{Part: 1, Input: "1abc2\npqr3stu8vwx\n", Answer: "38"},
+		// The last example of a part is the one with the answer.
+		{Part: 2, Input: "eightwothree\n", Answer: "83"},
+		// Parts without an example reuse the previous one.
+		{Part: 3, Input: "eightwothree\n", Answer: "-1"},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Extract() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractFailures(t *testing.T) {
+	pages := []string{
+		"<html>No puzzle here</html>",
+		`<article class="day-desc"><p>The answer is <code><em>1</em></code>.</p></article>`,
+		`<article class="day-desc"><pre><code>1 2 3</code></pre></article>`,
+	}
+	for _, p := range pages {
+		if _, err := examples.Extract(strings.NewReader(p)); err == nil {
+			t.Errorf("Extract(%q) succeeded, want an error", p)
+		}
+	}
+}
+
+func TestFixturesRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	want := []examples.Example{
+		{Part: 1, Input: "1\n2\n", Answer: "3"},
+		{Part: 2, Input: "4\n", Answer: "4"},
+	}
+	if err := examples.WriteFixtures(dir, 9, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := examples.LoadFixtures(dir, 9)
+	if err != nil || fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("LoadFixtures() = %q, %v, want %q", got, err, want)
+	}
+}
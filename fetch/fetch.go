@@ -0,0 +1,202 @@
+// Package fetch downloads puzzle inputs, following the site's guidelines
+// for automated tools: every input is downloaded once and then cached,
+// requests are throttled, and the User-Agent names the tool.
+package fetch
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dinord/aoc23/parse"
+	"github.com/dinord/aoc23/solver"
+)
+
+type Config struct {
+	// The value of the `session` cookie of a logged in browser.
+	Session string
+	BaseURL string
+	// Inputs of day N of year Y are cached in `<CacheDir>/Y/N.txt`.
+	CacheDir  string
+	UserAgent string
+	// The shortest time between two requests, even across runs.
+	MinInterval time.Duration
+}
+
+var DefaultConfig = Config{
+	BaseURL:     "https://adventofcode.com",
+	CacheDir:    "inputs",
+	UserAgent:   "github.com/dinord/aoc23",
+	MinInterval: 15 * time.Minute,
+}
+
+// Returns the default path of the config file, see LoadConfig.
+func DefaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aoc23", "config")
+}
+
+// Loads a config file of parse.KeyValueLines, like:
+//
+//	session: 53616c7465645f5f...
+//	base_url: https://adventofcode.com
+//	cache_dir: inputs
+//	user_agent: github.com/dinord/aoc23 by me@example.com
+//	min_interval: 15m
+//
+// Missing keys keep their values from DefaultConfig.
+func LoadConfig(path string) (c Config, err error) {
+	c = DefaultConfig
+	file, err := os.Open(path)
+	if err != nil {
+		return c, err
+	}
+	defer file.Close()
+
+	err = parse.KeyValueLines(file, func(key parse.Field, value parse.Field) (err error) {
+		switch key.Text {
+		case "session":
+			c.Session = value.Text
+		case "base_url":
+			c.BaseURL = strings.TrimSuffix(value.Text, "/")
+		case "cache_dir":
+			c.CacheDir = value.Text
+		case "user_agent":
+			c.UserAgent = value.Text
+		case "min_interval":
+			c.MinInterval, err = time.ParseDuration(value.Text)
+			if err != nil {
+				return value.Errorf("Expected a duration like `15m`, got: %s", value.Text)
+			}
+		default:
+			return key.Errorf("Unknown key: %s", key.Text)
+		}
+		return nil
+	})
+	if err != nil {
+		return c, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+type Client struct {
+	Config Config
+	HTTP   *http.Client
+	// Returns the current time, replaceable in tests.
+	Now func() time.Time
+}
+
+func NewClient(c Config) *Client {
+	return &Client{Config: c, HTTP: &http.Client{Timeout: time.Minute}, Now: time.Now}
+}
+
+// Puzzles unlock at midnight in the US Eastern time zone, which is
+// always five hours behind UTC in December.
+func unlockTime(year int, day int) time.Time {
+	return time.Date(year, time.December, day, 5, 0, 0, 0, time.UTC)
+}
+
+func (c *Client) CachePath(year int, day int) string {
+	return filepath.Join(c.Config.CacheDir, strconv.Itoa(year), strconv.Itoa(day)+".txt")
+}
+
+// Remembers when the last request was sent, shared by all years.
+func (c *Client) stampPath() string {
+	return filepath.Join(c.Config.CacheDir, ".last_request")
+}
+
+// Fails if the last request was sent less than MinInterval ago.
+func (c *Client) throttle() error {
+	data, err := os.ReadFile(c.stampPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	last, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("%s: %w", c.stampPath(), err)
+	}
+	if next := last.Add(c.Config.MinInterval); c.Now().Before(next) {
+		return solver.Errorf(solver.UsageError, "Throttled: the last request was at %s, try again after %s",
+			last.Format(time.RFC3339), next.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Returns the path of the cached input of `day` in `year`,
+// downloading it first if it is not cached yet.
+func (c *Client) Input(year int, day int) (path string, err error) {
+	path = c.CachePath(year, day)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	if c.Config.Session == "" {
+		return "", solver.Errorf(solver.UsageError, "Expected a session token in the config to download inputs")
+	}
+	if day < 1 || day > 25 {
+		return "", solver.Errorf(solver.UsageError, "Expected a day from 1 to 25, got: %d", day)
+	}
+	if unlock := unlockTime(year, day); c.Now().Before(unlock) {
+		return "", solver.Errorf(solver.UsageError, "Day %d of %d unlocks at %s", day, year, unlock.Format(time.RFC3339))
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := c.throttle(); err != nil {
+		return "", err
+	}
+
+	input, err := c.download(year, day)
+	if err != nil {
+		return "", err
+	}
+	// Write to a temporary file first, so a failed write is not cached.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, input, 0o644); err != nil {
+		return "", err
+	}
+	return path, os.Rename(tmp, path)
+}
+
+func (c *Client) download(year int, day int) ([]byte, error) {
+	url := fmt.Sprintf("%s/%d/day/%d/input", c.Config.BaseURL, year, day)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, solver.Errorf(solver.UsageError, "Bad base URL: %w", err)
+	}
+	request.Header.Set("User-Agent", c.Config.UserAgent)
+	request.AddCookie(&http.Cookie{Name: "session", Value: c.Config.Session})
+
+	// Count failed requests too, they load the site just the same.
+	stamp := c.Now().UTC().Format(time.RFC3339) + "\n"
+	if err := os.WriteFile(c.stampPath(), []byte(stamp), 0o644); err != nil {
+		return nil, err
+	}
+	response, err := c.HTTP.Do(request)
+	if err != nil {
+		return nil, &solver.Error{Kind: solver.IOError, Err: err}
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, &solver.Error{Kind: solver.IOError, Err: err}
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, solver.Errorf(solver.IOError, "GET %s: %s: %s", url, response.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,141 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A stand-in for the puzzle site, which counts the requests it serves.
+type fakeSite struct {
+	requests int
+}
+
+func (s *fakeSite) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests++
+	cookie, err := r.Cookie("session")
+	if err != nil || cookie.Value != "secret" {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		return
+	}
+	if r.UserAgent() != "aoc23 test" {
+		http.Error(w, "Unknown user agent", http.StatusForbidden)
+		return
+	}
+	if r.URL.Path != "/2023/day/6/input" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Write([]byte("Time: 7 15 30\nDistance: 9 40 200\n"))
+}
+
+func newTestClient(t *testing.T, url string) *Client {
+	c := NewClient(Config{
+		Session:     "secret",
+		BaseURL:     url,
+		CacheDir:    t.TempDir(),
+		UserAgent:   "aoc23 test",
+		MinInterval: 15 * time.Minute,
+	})
+	c.Now = func() time.Time { return time.Date(2023, time.December, 10, 12, 0, 0, 0, time.UTC) }
+	return c
+}
+
+func TestInputIsFetchedOnce(t *testing.T) {
+	site := &fakeSite{}
+	server := httptest.NewServer(site)
+	defer server.Close()
+	c := newTestClient(t, server.URL)
+
+	for i := 0; i < 2; i++ {
+		path, err := c.Input(2023, 6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := filepath.Join(c.Config.CacheDir, "2023", "6.txt"); path != want {
+			t.Errorf("Input() = %s, want %s", path, want)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || !strings.HasPrefix(string(data), "Time:") {
+			t.Errorf("Cached input is %q, %v", data, err)
+		}
+	}
+	if site.requests != 1 {
+		t.Errorf("Sent %d requests, want 1", site.requests)
+	}
+}
+
+func TestInputIsThrottled(t *testing.T) {
+	site := &fakeSite{}
+	server := httptest.NewServer(site)
+	defer server.Close()
+	c := newTestClient(t, server.URL)
+
+	// Day 5 does not exist on the fake site, but still counts as a request.
+	if _, err := c.Input(2023, 5); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Input(2023, 5) = %v, want a 404 error", err)
+	}
+	if _, err := os.Stat(c.CachePath(2023, 5)); err == nil {
+		t.Errorf("Failed download was cached")
+	}
+	if _, err := c.Input(2023, 6); err == nil || !strings.Contains(err.Error(), "Throttled") {
+		t.Errorf("Input(2023, 6) = %v, want to be throttled", err)
+	}
+
+	now := c.Now()
+	c.Now = func() time.Time { return now.Add(15 * time.Minute) }
+	if _, err := c.Input(2023, 6); err != nil {
+		t.Errorf("Input(2023, 6) = %v after waiting", err)
+	}
+	if site.requests != 2 {
+		t.Errorf("Sent %d requests, want 2", site.requests)
+	}
+}
+
+func TestInputFailures(t *testing.T) {
+	site := &fakeSite{}
+	server := httptest.NewServer(site)
+	defer server.Close()
+
+	c := newTestClient(t, server.URL)
+	if _, err := c.Input(2023, 11); err == nil || !strings.Contains(err.Error(), "unlocks") {
+		t.Errorf("Input(2023, 11) = %v, want an error before the puzzle unlocks", err)
+	}
+
+	c = newTestClient(t, server.URL)
+	c.Config.Session = "stale"
+	if _, err := c.Input(2023, 6); err == nil || !strings.Contains(err.Error(), "Please log in") {
+		t.Errorf("Input(2023, 6) = %v, want an error for a bad session", err)
+	}
+
+	c = newTestClient(t, server.URL)
+	c.Config.Session = ""
+	if _, err := c.Input(2023, 6); err == nil {
+		t.Errorf("Input(2023, 6) succeeded without a session")
+	}
+	if site.requests != 1 {
+		t.Errorf("Sent %d requests, want 1", site.requests)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	os.WriteFile(path, []byte("# My config\nsession: secret\nbase_url: http://localhost:8080/\nmin_interval: 5m\n"), 0o644)
+	c, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Session != "secret" || c.BaseURL != "http://localhost:8080" || c.MinInterval != 5*time.Minute ||
+		c.CacheDir != DefaultConfig.CacheDir || c.UserAgent != DefaultConfig.UserAgent {
+		t.Errorf("LoadConfig() = %+v", c)
+	}
+
+	os.WriteFile(path, []byte("session: secret\nmin_interval: soon\n"), 0o644)
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "Line 2") {
+		t.Errorf("LoadConfig() = %v, want an error on line 2", err)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/dinord/aoc23
+
+go 1.23
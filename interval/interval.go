@@ -0,0 +1,186 @@
+// Package interval implements half-open integer ranges and sets of them.
+package interval
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+type Range struct {
+	Start int // included
+	End   int // not included
+}
+
+func (r Range) Empty() bool {
+	return r.End <= r.Start
+}
+
+// Returns the number of values in the range, zero if it is empty.
+func (r Range) Len() int {
+	if r.Empty() {
+		return 0
+	}
+	return r.End - r.Start
+}
+
+func (r Range) Contains(v int) bool {
+	return r.Start <= v && v < r.End
+}
+
+// Returns the values in both ranges. The result may be empty.
+func (r Range) Intersect(other Range) Range {
+	return Range{Start: max(r.Start, other.Start), End: min(r.End, other.End)}
+}
+
+// Moves the range by `offset`. The caller must make sure this does not overflow.
+func (r Range) Shift(offset int) Range {
+	return Range{Start: r.Start + offset, End: r.End + offset}
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("[%d, %d)", r.Start, r.End)
+}
+
+// Encodes the range as a `[start, end]` pair, with `end` not included.
+func (r Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int{r.Start, r.End})
+}
+
+// Sorts `rs` and merges overlapping and adjacent ranges, dropping empty ones.
+// The result is sorted by start, and its ranges neither overlap nor touch.
+func Normalize(rs []Range) []Range {
+	sorted := make([]Range, 0, len(rs))
+	for _, r := range rs {
+		if !r.Empty() {
+			sorted = append(sorted, r)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b Range) int { return cmp.Compare(a.Start, b.Start) })
+
+	merged := sorted[:0]
+	for _, r := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && r.Start <= merged[last].End {
+			merged[last].End = max(merged[last].End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// A set of integers, stored as normalized ranges.
+// The zero value is the empty set. Sets are immutable.
+type Set struct {
+	ranges []Range
+}
+
+func NewSet(rs ...Range) Set {
+	return Set{ranges: Normalize(rs)}
+}
+
+// Returns the normalized ranges of the set. The caller must not modify them.
+func (s Set) Ranges() []Range {
+	return s.ranges
+}
+
+// Iterates over the normalized ranges of the set, in increasing order.
+func (s Set) All() iter.Seq[Range] {
+	return slices.Values(s.ranges)
+}
+
+func (s Set) Empty() bool {
+	return len(s.ranges) == 0
+}
+
+// Returns the number of values in the set.
+func (s Set) Len() int {
+	n := 0
+	for _, r := range s.ranges {
+		n += r.Len()
+	}
+	return n
+}
+
+// Returns the smallest value in the set, or false if the set is empty.
+func (s Set) Min() (int, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.ranges[0].Start, true
+}
+
+func (s Set) Contains(v int) bool {
+	i, found := slices.BinarySearchFunc(s.ranges, v, func(r Range, v int) int {
+		if r.End <= v {
+			return -1
+		}
+		if r.Start > v {
+			return 1
+		}
+		return 0
+	})
+	return found && s.ranges[i].Contains(v)
+}
+
+func (s Set) Union(other Set) Set {
+	return NewSet(slices.Concat(s.ranges, other.ranges)...)
+}
+
+func (s Set) Intersect(other Set) Set {
+	result := make([]Range, 0, max(len(s.ranges), len(other.ranges)))
+	i, j := 0, 0
+	for i < len(s.ranges) && j < len(other.ranges) {
+		r := s.ranges[i].Intersect(other.ranges[j])
+		if !r.Empty() {
+			result = append(result, r)
+		}
+		if s.ranges[i].End < other.ranges[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return Set{ranges: result}
+}
+
+// Returns the values in `s` that are not in `other`.
+func (s Set) Difference(other Set) Set {
+	result := make([]Range, 0, len(s.ranges))
+	j := 0
+	for _, r := range s.ranges {
+		start := r.Start
+		// Ranges in `other` that end before `start` cannot affect
+		// this or any following range of `s`.
+		for j < len(other.ranges) && other.ranges[j].End <= start {
+			j++
+		}
+		for k := j; k < len(other.ranges) && other.ranges[k].Start < r.End; k++ {
+			if other.ranges[k].Start > start {
+				result = append(result, Range{Start: start, End: other.ranges[k].Start})
+			}
+			start = max(start, other.ranges[k].End)
+		}
+		if start < r.End {
+			result = append(result, Range{Start: start, End: r.End})
+		}
+	}
+	return Set{ranges: result}
+}
+
+// Moves every value in the set by `offset`.
+// The caller must make sure this does not overflow.
+func (s Set) Shift(offset int) Set {
+	result := make([]Range, len(s.ranges))
+	for i, r := range s.ranges {
+		result[i] = r.Shift(offset)
+	}
+	return Set{ranges: result}
+}
+
+func (s Set) String() string {
+	return fmt.Sprint(s.ranges)
+}
@@ -0,0 +1,113 @@
+package interval
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []Range
+		want []Range
+	}{
+		{"nil", nil, []Range{}},
+		{"drops empty", []Range{{3, 3}, {5, 2}}, []Range{}},
+		{"sorts", []Range{{5, 6}, {1, 2}}, []Range{{1, 2}, {5, 6}}},
+		{"merges overlapping", []Range{{1, 5}, {3, 8}}, []Range{{1, 8}}},
+		{"merges adjacent", []Range{{1, 5}, {5, 8}}, []Range{{1, 8}}},
+		{"merges contained", []Range{{1, 10}, {2, 3}, {4, 12}}, []Range{{1, 12}}},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Normalize(%v) = %v, want %v", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := NewSet(Range{0, 10}, Range{20, 30})
+	b := NewSet(Range{5, 25})
+	tests := []struct {
+		name string
+		got  Set
+		want []Range
+	}{
+		{"union", a.Union(b), []Range{{0, 30}}},
+		{"intersect", a.Intersect(b), []Range{{5, 10}, {20, 25}}},
+		{"difference", a.Difference(b), []Range{{0, 5}, {25, 30}}},
+		{"reverse difference", b.Difference(a), []Range{{10, 20}}},
+		{"shift", a.Shift(-5), []Range{{-5, 5}, {15, 25}}},
+		{"empty intersect", a.Intersect(Set{}), []Range{}},
+		{"empty difference", Set{}.Difference(a), []Range{}},
+	}
+	for _, tt := range tests {
+		if got := tt.got.Ranges(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetQueries(t *testing.T) {
+	s := NewSet(Range{20, 30}, Range{0, 10})
+	if got := s.Len(); got != 20 {
+		t.Errorf("Len() = %d, want 20", got)
+	}
+	if got, ok := s.Min(); !ok || got != 0 {
+		t.Errorf("Min() = %d, %v, want 0, true", got, ok)
+	}
+	if _, ok := (Set{}).Min(); ok {
+		t.Errorf("Min() of empty set should fail")
+	}
+	for v, want := range map[int]bool{-1: false, 0: true, 9: true, 10: false, 19: false, 20: true, 29: true, 30: false} {
+		if got := s.Contains(v); got != want {
+			t.Errorf("Contains(%d) = %v, want %v", v, got, want)
+		}
+	}
+	got := make([]Range, 0)
+	for r := range s.All() {
+		got = append(got, r)
+	}
+	if want := []Range{{0, 10}, {20, 30}}; !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+}
+
+// Compares set operations with the same operations on small bitmaps.
+func TestSetOperationsMatchBitmaps(t *testing.T) {
+	const Size = 40
+	random := rand.New(rand.NewSource(1))
+	randomSet := func() (Set, [Size]bool) {
+		var bits [Size]bool
+		rs := make([]Range, random.Intn(5))
+		for i := range rs {
+			start := random.Intn(Size)
+			rs[i] = Range{Start: start, End: start + random.Intn(Size-start+1)}
+			for v := rs[i].Start; v < rs[i].End; v++ {
+				bits[v] = true
+			}
+		}
+		return NewSet(rs...), bits
+	}
+	check := func(name string, s Set, want func(v int) bool) {
+		for i, r := range s.Ranges() {
+			if r.Empty() || (i > 0 && r.Start <= s.Ranges()[i-1].End) {
+				t.Fatalf("%s: ranges not normalized: %v", name, s)
+			}
+		}
+		for v := 0; v < Size; v++ {
+			if s.Contains(v) != want(v) {
+				t.Fatalf("%s: Contains(%d) = %v, want %v in %v", name, v, s.Contains(v), want(v), s)
+			}
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		a, aBits := randomSet()
+		b, bBits := randomSet()
+		check("union", a.Union(b), func(v int) bool { return aBits[v] || bBits[v] })
+		check("intersect", a.Intersect(b), func(v int) bool { return aBits[v] && bBits[v] })
+		check("difference", a.Difference(b), func(v int) bool { return aBits[v] && !bBits[v] })
+	}
+}
@@ -0,0 +1,113 @@
+// Package known keeps the answers that the solvers gave for our puzzle
+// inputs, so that changed answers stand out after a refactor.
+//
+// The answers of day N are kept in `<dir>/N.txt`, one line per part
+// and input, like:
+//
+//	# part input_sha256 answer
+//	1 5feceb66ffc86f38d952786c6d696c79c2dbc239dd4e91b46729d73a27fb57e9 54644
+//
+// Inputs are identified by the SHA-256 of their uncompressed contents.
+package known
+
+import (
+	"bufio"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dinord/aoc23/parse"
+	"github.com/dinord/aoc23/solver"
+)
+
+type Key struct {
+	Part      int
+	InputHash string
+}
+
+// Answers of a single day.
+type Answers map[Key]solver.Answer
+
+// Returns the hex SHA-256 of everything in `input`.
+func HashInput(input io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, input); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func path(dir string, day int) string {
+	return filepath.Join(dir, strconv.Itoa(day)+".txt")
+}
+
+// Loads the answers of `day` from `dir`. No file means no answers yet.
+func Load(dir string, day int) (Answers, error) {
+	answers := make(Answers)
+	file, err := os.Open(path(dir, day))
+	if errors.Is(err, fs.ErrNotExist) {
+		return answers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	err = parse.DataLines(file, func(line parse.Field) error {
+		fields := line.Fields()
+		if len(fields) != 3 {
+			return fmt.Errorf("Expected `<part> <input_sha256> <answer>`, got: %s", strings.TrimSpace(line.Text))
+		}
+		part, err := fields[0].Int()
+		if err != nil {
+			return err
+		}
+		answers[Key{Part: part, InputHash: fields[1].Text}] = solver.Answer(fields[2].Text)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file.Name(), err)
+	}
+	return answers, nil
+}
+
+// Saves the answers of `day` to `dir`, ordered by part and input.
+func (answers Answers) Save(dir string, day int) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	keys := make([]Key, 0, len(answers))
+	for k, answer := range answers {
+		if len(strings.Fields(string(answer))) != 1 {
+			return fmt.Errorf("Day %d part %d: Expected an answer without whitespace, got: %q", day, k.Part, answer)
+		}
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b Key) int {
+		return cmp.Or(cmp.Compare(a.Part, b.Part), cmp.Compare(a.InputHash, b.InputHash))
+	})
+
+	file, err := os.Create(path(dir, day))
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+	fmt.Fprintln(w, "# part input_sha256 answer")
+	for _, k := range keys {
+		fmt.Fprintf(w, "%d %s %s\n", k.Part, k.InputHash, answers[k])
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
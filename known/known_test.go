@@ -0,0 +1,57 @@
+package known
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHashInput(t *testing.T) {
+	hash, err := HashInput(strings.NewReader("0"))
+	want := "5feceb66ffc86f38d952786c6d696c79c2dbc239dd4e91b46729d73a27fb57e9"
+	if err != nil || hash != want {
+		t.Errorf("HashInput(\"0\") = %s, %v, want %s", hash, err, want)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "answers")
+	answers, err := Load(dir, 5)
+	if err != nil || len(answers) != 0 {
+		t.Fatalf("Load() = %v, %v, want no answers", answers, err)
+	}
+
+	answers = Answers{
+		{Part: 2, InputHash: "b"}: "46",
+		{Part: 1, InputHash: "b"}: "35",
+		{Part: 1, InputHash: "a"}: "1234",
+	}
+	if err := answers.Save(dir, 5); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "5.txt"))
+	if want := "# part input_sha256 answer\n1 a 1234\n1 b 35\n2 b 46\n"; err != nil || string(data) != want {
+		t.Errorf("Saved %q, %v, want %q", data, err, want)
+	}
+	loaded, err := Load(dir, 5)
+	if err != nil || !maps.Equal(loaded, answers) {
+		t.Errorf("Load() = %v, %v, want %v", loaded, err, answers)
+	}
+}
+
+func TestSaveRejectsWhitespace(t *testing.T) {
+	answers := Answers{{Part: 1, InputHash: "a"}: "two words"}
+	if err := answers.Save(t.TempDir(), 1); err == nil {
+		t.Errorf("Save() succeeded, want an error")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "1.txt"), []byte("# comment\n1 a 2\nx a 2\n"), 0o644)
+	if _, err := Load(dir, 1); err == nil || !strings.Contains(err.Error(), "Line 3") {
+		t.Errorf("Load() = %v, want an error on line 3", err)
+	}
+}
@@ -0,0 +1,380 @@
+// Package parse reads puzzle inputs: lines, blocks of lines separated
+// by blank lines, whitespace separated fields and integers, `Key: value`
+// lines and character grids.
+//
+// Errors carry the line and column where the input went wrong,
+// both starting from 1, see Error.
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// An input error at a line and column, both starting from 1.
+type Error struct {
+	Line int // zero if unknown
+	Col  int // zero if unknown
+	Err  error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Line == 0 && e.Col == 0:
+		return e.Err.Error()
+	case e.Line == 0:
+		return fmt.Sprintf("Column %d: %v", e.Col, e.Err)
+	case e.Col == 0:
+		return fmt.Sprintf("Line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("Line %d, column %d: %v", e.Line, e.Col, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Records that `err` happened on `line`. If `err` already is an Error,
+// sets its line instead of wrapping it again, unless it has one.
+func AtLine(line int, err error) error {
+	if err == nil {
+		return nil
+	}
+	var parseErr *Error
+	if errors.As(err, &parseErr) {
+		if parseErr.Line == 0 {
+			parseErr.Line = line
+		}
+		return err
+	}
+	return &Error{Line: line, Err: err}
+}
+
+// Reading the input failed, as opposed to the input being malformed.
+type ReadError struct {
+	Err error
+}
+
+func (e *ReadError) Error() string {
+	return fmt.Sprintf("Failed to read input: %v", e.Err)
+}
+
+func (e *ReadError) Unwrap() error {
+	return e.Err
+}
+
+// A piece of an input line and the column it starts at.
+type Field struct {
+	Text string
+	Col  int
+}
+
+// Returns an error at the start of the field.
+func (f Field) Errorf(format string, args ...any) error {
+	return &Error{Col: f.Col, Err: fmt.Errorf(format, args...)}
+}
+
+// Returns the part of the field starting at byte offset `i`.
+func (f Field) from(i int) Field {
+	return Field{Text: f.Text[i:], Col: f.Col + utf8.RuneCountInString(f.Text[:i])}
+}
+
+func (f Field) TrimSpace() Field {
+	f = f.from(len(f.Text) - len(strings.TrimLeftFunc(f.Text, unicode.IsSpace)))
+	f.Text = strings.TrimRightFunc(f.Text, unicode.IsSpace)
+	return f
+}
+
+// Like strings.Cut, but keeps track of columns.
+func (f Field) Cut(sep string) (before Field, after Field, found bool) {
+	i := strings.Index(f.Text, sep)
+	if i == -1 {
+		return f, Field{}, false
+	}
+	return Field{Text: f.Text[:i], Col: f.Col}, f.from(i + len(sep)), true
+}
+
+// Splits the field around `sep`, e.g. the sets of a game `1 red; 2 blue`.
+func (f Field) Split(sep string) []Field {
+	fields := make([]Field, 0, 10) // arbitrary capacity
+	for {
+		before, after, found := f.Cut(sep)
+		fields = append(fields, before)
+		if !found {
+			return fields
+		}
+		f = after
+	}
+}
+
+// Splits the field around runs of whitespace.
+func (f Field) Fields() []Field {
+	fields := make([]Field, 0, 10) // arbitrary capacity
+	start := -1                    // byte offset of the current field
+	for i, r := range f.Text {
+		if !unicode.IsSpace(r) {
+			if start == -1 {
+				start = i
+				fields = append(fields, f.from(i))
+			}
+			continue
+		}
+		if start != -1 {
+			fields[len(fields)-1].Text = f.Text[start:i]
+			start = -1
+		}
+	}
+	return fields
+}
+
+// Splits a line like `Card 1: 41 48 | 83 86` into its key and its value,
+// both without surrounding whitespace.
+func (f Field) KeyValue() (key Field, value Field, err error) {
+	key, value, found := f.Cut(":")
+	if !found {
+		return key, value, f.Errorf("Expected `<key>: <value>`, got: %s", f.Text)
+	}
+	return key.TrimSpace(), value.TrimSpace(), nil
+}
+
+// Returns the value of a line like `Time: 7 15 30`, where `key` is `Time`.
+func (f Field) Value(key string) (Field, error) {
+	k, value, err := f.KeyValue()
+	if err != nil || k.Text != key {
+		return value, f.Errorf("Expected prefix `%s:`, got: %s", key, f.Text)
+	}
+	return value, nil
+}
+
+func (f Field) Int() (int, error) {
+	i, err := strconv.Atoi(f.Text)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, f.Errorf("Integer %s overflows %d-bit integers", f.Text, strconv.IntSize)
+	}
+	if err != nil {
+		return 0, f.Errorf("Expected an integer, got: %s", f.Text)
+	}
+	return i, nil
+}
+
+// Parses whitespace separated integers, each with an optional sign.
+func (f Field) Ints() ([]int, error) {
+	fields := f.Fields()
+	ints := make([]int, len(fields))
+	for i, field := range fields {
+		var err error
+		ints[i], err = field.Int()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ints, nil
+}
+
+// Parses whitespace separated integers, each with an optional sign.
+func Ints(s string) ([]int, error) {
+	return Field{Text: s, Col: 1}.Ints()
+}
+
+// Reads the input line by line, keeping track of line numbers.
+//
+// Unlike bufio.Scanner, lines can be arbitrarily long. Like it, lines end
+// with an optional carriage return and a newline, which are dropped.
+type Scanner struct {
+	reader *bufio.Reader
+	buf    []byte
+	text   string
+	line   int
+	err    error // io.EOF at the end of the input
+}
+
+func NewScanner(input io.Reader) *Scanner {
+	return &Scanner{reader: bufio.NewReader(input)}
+}
+
+// Reads the next line. Returns false at the end of the input,
+// or if reading fails, see Err.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	s.buf = s.buf[:0]
+	for {
+		chunk, err := s.reader.ReadSlice('\n')
+		s.buf = append(s.buf, chunk...)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF && len(s.buf) > 0 {
+			// The last line has no newline.
+			break
+		}
+		if err != nil {
+			// Do not return a line cut short by a read error.
+			s.err = err
+			return false
+		}
+		break
+	}
+	line := bytes.TrimSuffix(s.buf, []byte("\n"))
+	s.text = string(bytes.TrimSuffix(line, []byte("\r")))
+	s.line++
+	return true
+}
+
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+// Returns the current line as a field starting at column 1.
+func (s *Scanner) Field() Field {
+	return Field{Text: s.Text(), Col: 1}
+}
+
+// Returns the number of the current line, starting from 1.
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+// Returns the first read error, if any, as a ReadError.
+func (s *Scanner) Err() error {
+	if s.err == nil || s.err == io.EOF {
+		return nil
+	}
+	return &ReadError{Err: s.err}
+}
+
+// Records that `err` happened on the current line, see AtLine.
+func (s *Scanner) Wrap(err error) error {
+	return AtLine(s.line, err)
+}
+
+// Scans the next line, failing with an error that mentions `what`
+// if the input ends first.
+func (s *Scanner) Expect(what string) (Field, error) {
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return Field{}, err
+		}
+		return Field{}, &Error{Line: s.line + 1, Err: fmt.Errorf("Expected %s, got the end of the input", what)}
+	}
+	return s.Field(), nil
+}
+
+// Reads all lines of the input.
+func Lines(input io.Reader) (lines []string, err error) {
+	scanner := NewScanner(input)
+	lines = make([]string, 0, 10) // arbitrary capacity
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// Calls `f` with every line of the input, skipping empty lines and
+// comments starting with `#`. Errors from `f` are recorded at the line.
+func DataLines(input io.Reader, f func(line Field) error) error {
+	scanner := NewScanner(input)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := f(scanner.Field()); err != nil {
+			return scanner.Wrap(err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Calls `f` with the key and value of every line like `key: value`,
+// skipping lines like DataLines does, e.g. for config files.
+func KeyValueLines(input io.Reader, f func(key Field, value Field) error) error {
+	return DataLines(input, func(line Field) error {
+		key, value, err := line.KeyValue()
+		if err != nil {
+			return err
+		}
+		return f(key, value)
+	})
+}
+
+// Lines of the input between blank lines.
+type Block struct {
+	Line  int // of the first line in the block
+	Lines []string
+}
+
+// Returns the `i`-th line of the block as a field starting at column 1.
+func (b Block) Field(i int) Field {
+	return Field{Text: b.Lines[i], Col: 1}
+}
+
+// Records that `err` happened on the `i`-th line of the block.
+func (b Block) Wrap(i int, err error) error {
+	return AtLine(b.Line+i, err)
+}
+
+// Reads the non-empty blocks of lines separated by blank lines.
+func Blocks(input io.Reader) (blocks []Block, err error) {
+	scanner := NewScanner(input)
+	blocks = make([]Block, 0, 10) // arbitrary capacity
+	inBlock := false
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			inBlock = true
+			blocks = append(blocks, Block{Line: scanner.Line()})
+		}
+		last := &blocks[len(blocks)-1]
+		last.Lines = append(last.Lines, scanner.Text())
+	}
+	return blocks, scanner.Err()
+}
+
+// A rectangle of characters, one byte each.
+type Grid struct {
+	Rows []string
+}
+
+func (g Grid) Height() int {
+	return len(g.Rows)
+}
+
+func (g Grid) Width() int {
+	if len(g.Rows) == 0 {
+		return 0
+	}
+	return len(g.Rows[0])
+}
+
+// Reads a grid, failing unless all lines have the same length.
+// Trailing blank lines are ignored.
+func ReadGrid(input io.Reader) (g Grid, err error) {
+	g.Rows, err = Lines(input)
+	if err != nil {
+		return g, err
+	}
+	for len(g.Rows) > 0 && g.Rows[len(g.Rows)-1] == "" {
+		g.Rows = g.Rows[:len(g.Rows)-1]
+	}
+	if len(g.Rows) == 0 {
+		return g, &Error{Line: 1, Err: errors.New("Expected a grid, got an empty input")}
+	}
+	for i, row := range g.Rows {
+		if len(row) != g.Width() {
+			return g, &Error{Line: i + 1, Err: fmt.Errorf("Expected %d characters like the first line, got %d", g.Width(), len(row))}
+		}
+	}
+	return g, nil
+}
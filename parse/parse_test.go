@@ -0,0 +1,150 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []int
+	}{
+		{"", []int{}},
+		{"1 2 3", []int{1, 2, 3}},
+		{"  -4\t+5   6 ", []int{-4, 5, 6}},
+	}
+	for _, tt := range tests {
+		got, err := Ints(tt.s)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("Ints(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+}
+
+func TestErrorColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		col  int
+	}{
+		{"bad int", func() error { _, err := Ints("1  2x 3"); return err }(), 4},
+		{"bad int after key", func() error {
+			_, value, _ := Field{Text: "Card 1:  4 é 5", Col: 1}.KeyValue()
+			_, err := value.Ints()
+			return err
+		}(), 12},
+		{"bad int after cut", func() error {
+			_, after, _ := Field{Text: "1 2 | 3 ?", Col: 1}.Cut("|")
+			_, err := after.Ints()
+			return err
+		}(), 9},
+		{"missing key", func() error { _, err := Field{Text: "Time 7", Col: 1}.Value("Time"); return err }(), 1},
+	}
+	for _, tt := range tests {
+		var parseErr *Error
+		if !errors.As(tt.err, &parseErr) || parseErr.Col != tt.col {
+			t.Errorf("%s: got %v, want error at column %d", tt.name, tt.err, tt.col)
+		}
+	}
+}
+
+func TestAtLine(t *testing.T) {
+	err := AtLine(3, Field{Text: "x", Col: 5}.Errorf("Bad"))
+	if got, want := err.Error(), "Line 3, column 5: Bad"; got != want {
+		t.Errorf("AtLine() = %q, want %q", got, want)
+	}
+	// The innermost line wins.
+	err = AtLine(7, err)
+	if got, want := err.Error(), "Line 3, column 5: Bad"; got != want {
+		t.Errorf("AtLine() = %q, want %q", got, want)
+	}
+}
+
+func TestScannerLines(t *testing.T) {
+	long := strings.Repeat("x", 1<<20)
+	input := "a\r\n" + long + "\n\nlast"
+	lines, err := Lines(strings.NewReader(input))
+	want := []string{"a", long, "", "last"}
+	if err != nil || !slices.Equal(lines, want) {
+		t.Errorf("Lines() = %d lines, %v, want %d lines", len(lines), err, len(want))
+	}
+}
+
+func TestScannerReadError(t *testing.T) {
+	readErr := errors.New("disk on fire")
+	scanner := NewScanner(io.MultiReader(strings.NewReader("a\nb"), iotest.ErrReader(readErr)))
+	lines := make([]string, 0)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	// The cut short line `b` is not returned.
+	var errRead *ReadError
+	if !slices.Equal(lines, []string{"a"}) || !errors.As(scanner.Err(), &errRead) || !errors.Is(scanner.Err(), readErr) {
+		t.Errorf("Scanned %q, %v, want [a] and a read error", lines, scanner.Err())
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	blocks, err := Blocks(strings.NewReader("a\nb\n\n\nc\n  \nd\ne\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Block{
+		{Line: 1, Lines: []string{"a", "b"}},
+		{Line: 5, Lines: []string{"c"}},
+		{Line: 7, Lines: []string{"d", "e"}},
+	}
+	if !slices.EqualFunc(blocks, want, func(a, b Block) bool {
+		return a.Line == b.Line && slices.Equal(a.Lines, b.Lines)
+	}) {
+		t.Errorf("Blocks() = %v, want %v", blocks, want)
+	}
+}
+
+func TestReadGrid(t *testing.T) {
+	g, err := ReadGrid(strings.NewReader("ab.\n.cd\n\n"))
+	if err != nil || g.Width() != 3 || g.Height() != 2 {
+		t.Errorf("ReadGrid() = %v, %v, want a 3x2 grid", g, err)
+	}
+
+	_, err = ReadGrid(strings.NewReader("abc\nab\n"))
+	var parseErr *Error
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("ReadGrid() = %v, want error on line 2", err)
+	}
+}
+
+func TestKeyValueLines(t *testing.T) {
+	input := "# comment\n\na: 1\n  # indented comment\nb:  two words \n"
+	got := make([]string, 0)
+	err := KeyValueLines(strings.NewReader(input), func(key Field, value Field) error {
+		got = append(got, key.Text+"="+value.Text)
+		return nil
+	})
+	if want := []string{"a=1", "b=two words"}; err != nil || !slices.Equal(got, want) {
+		t.Errorf("KeyValueLines() = %q, %v, want %q", got, err, want)
+	}
+
+	err = KeyValueLines(strings.NewReader("a: 1\n\nb: x\n"), func(key Field, value Field) error {
+		if key.Text == "b" {
+			_, err := value.Int()
+			return err
+		}
+		return nil
+	})
+	if got, want := fmt.Sprint(err), "Line 3, column 4: Expected an integer, got: x"; got != want {
+		t.Errorf("KeyValueLines() = %q, want %q", got, want)
+	}
+
+	err = KeyValueLines(strings.NewReader("no key\n"), func(Field, Field) error { return nil })
+	var parseErr *Error
+	if !errors.As(err, &parseErr) || parseErr.Line != 1 {
+		t.Errorf("KeyValueLines() = %v, want error on line 1", err)
+	}
+}
@@ -0,0 +1,116 @@
+package solver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Whether `path` stands for standard input.
+func IsStdin(path string) bool {
+	return path == "" || path == "-"
+}
+
+// Opens the puzzle input at `path`, or standard input if `path` is empty
+// or `-`. Files ending in `.gz` or `.zst` are decompressed while reading.
+//
+// The standard library cannot read zstd, so `.zst` files are piped
+// through the `zstd` command, which must be installed.
+func Open(path string) (io.ReadCloser, error) {
+	if IsStdin(path) {
+		return io.NopCloser(os.Stdin), nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.ReadCloser
+	switch filepath.Ext(path) {
+	case ".gz":
+		r, err = openGzip(file)
+	case ".zst":
+		r, err = openZstd(file)
+	default:
+		return file, nil
+	}
+	if err != nil {
+		file.Close()
+		return nil, &Error{Kind: IOError, Err: fmt.Errorf("%s: %w", path, err)}
+	}
+	return r, nil
+}
+
+// Decompresses a file, closing the file along with the decompressor.
+type decompressor struct {
+	io.Reader
+	close func() error
+	file  *os.File
+}
+
+func (d *decompressor) Close() error {
+	return errors.Join(d.close(), d.file.Close())
+}
+
+func openGzip(file *os.File) (io.ReadCloser, error) {
+	r, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	return &decompressor{Reader: r, close: r.Close, file: file}, nil
+}
+
+// Reads the output of `zstd`, failing at the end of the output
+// if `zstd` failed, e.g. because the file is corrupt.
+type zstdReader struct {
+	stdout io.ReadCloser
+	cmd    *exec.Cmd
+	stderr bytes.Buffer
+	done   bool
+}
+
+func (z *zstdReader) Read(p []byte) (int, error) {
+	n, err := z.stdout.Read(p)
+	if err == io.EOF && !z.done {
+		z.done = true
+		if waitErr := z.cmd.Wait(); waitErr != nil {
+			return n, fmt.Errorf("zstd failed: %v: %s", waitErr, strings.TrimSpace(z.stderr.String()))
+		}
+	}
+	return n, err
+}
+
+func (z *zstdReader) close() error {
+	if z.done {
+		return nil
+	}
+	// Stop decompressing input that was not read to the end.
+	z.done = true
+	z.cmd.Process.Kill()
+	z.cmd.Wait()
+	return nil
+}
+
+func openZstd(file *os.File) (io.ReadCloser, error) {
+	path, err := exec.LookPath("zstd")
+	if err != nil {
+		return nil, fmt.Errorf("Reading .zst files needs the zstd command: %w", err)
+	}
+	z := &zstdReader{cmd: exec.Command(path, "--decompress", "--stdout")}
+	z.cmd.Stdin = file
+	z.cmd.Stderr = &z.stderr
+	z.stdout, err = z.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := z.cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &decompressor{Reader: z, close: z.close, file: file}, nil
+}
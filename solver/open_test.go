@@ -0,0 +1,77 @@
+package solver
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const openTestInput = "Time: 7 15 30\nDistance: 9 40 200\n"
+
+func readAll(t *testing.T, path string) string {
+	t.Helper()
+	r, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open(%s) failed: %v", path, err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Reading %s failed: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestOpenGzip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "6.txt.gz")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := gzip.NewWriter(file)
+	io.WriteString(w, openTestInput)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	if got := readAll(t, path); got != openTestInput {
+		t.Errorf("Open(%s) read %q, want %q", path, got, openTestInput)
+	}
+}
+
+func TestOpenZstd(t *testing.T) {
+	zstd, err := exec.LookPath("zstd")
+	if err != nil {
+		t.Skip("No zstd command")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "6.txt")
+	if err := os.WriteFile(path, []byte(openTestInput), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := exec.Command(zstd, "-q", path).Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readAll(t, path+".zst"); got != openTestInput {
+		t.Errorf("Open(%s.zst) read %q, want %q", path, got, openTestInput)
+	}
+
+	// A corrupt file fails when reading, not when opening.
+	corrupt := filepath.Join(dir, "corrupt.txt.zst")
+	if err := os.WriteFile(corrupt, []byte("not zstd"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Open(corrupt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if _, err := io.ReadAll(r); err == nil {
+		t.Errorf("Reading %s succeeded, want an error", corrupt)
+	}
+}
@@ -0,0 +1,155 @@
+// Package solver keeps track of the puzzle solvers of every day.
+//
+// Each day's package registers itself when it is imported, so a binary
+// only needs to import the days it wants to run.
+package solver
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"runtime/debug"
+	"slices"
+	"strconv"
+
+	"github.com/dinord/aoc23/parse"
+)
+
+// The answer to a puzzle part, as it would be submitted.
+type Answer string
+
+// Solves both parts of a day's puzzle, reading the puzzle input from `input`.
+type Solver interface {
+	Part1(input io.Reader) (Answer, error)
+	Part2(input io.Reader) (Answer, error)
+}
+
+type Day struct {
+	Number int
+	Solver Solver
+	// Adds the day's own flags to `flags`, which already has the input
+	// and the puzzle part, and returns what runs the day once the flags
+	// are parsed, see cmd/aoc. Nil if the day has no flags of its own.
+	Command func(flags *flag.FlagSet) func(input io.Reader, part int) error
+}
+
+func Int(answer int) Answer {
+	return Answer(strconv.Itoa(answer))
+}
+
+// Tells apart errors that the command line reports differently.
+type ErrorKind int
+
+const (
+	// Malformed or unsolvable puzzle input. Errors of unknown kind
+	// are assumed to be input errors.
+	InputError ErrorKind = iota
+	// Bad flags or arguments.
+	UsageError
+	// Failing to open or read the input.
+	IOError
+	// A broken invariant, i.e. a bug in a solver rather than bad input.
+	InternalError
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case UsageError:
+		return "Usage error"
+	case IOError:
+		return "I/O error"
+	case InternalError:
+		return "Internal error"
+	}
+	return "Input error"
+}
+
+// An error of a known kind.
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func Errorf(kind ErrorKind, format string, args ...any) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// Returns the kind of `err`. Errors from opening and reading files
+// are I/O errors, even when not wrapped in an Error.
+func KindOf(err error) ErrorKind {
+	var solverErr *Error
+	var readErr *parse.ReadError
+	var pathErr *fs.PathError
+	switch {
+	case errors.As(err, &solverErr):
+		return solverErr.Kind
+	case errors.As(err, &readErr), errors.As(err, &pathErr):
+		return IOError
+	}
+	return InputError
+}
+
+// Solves `part` (1 or 2) of a puzzle. A panicking solver
+// fails with an InternalError.
+func Solve(s Solver, part int, input io.Reader) (answer Answer, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = Errorf(InternalError, "Solver panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+	switch part {
+	case 1:
+		return s.Part1(input)
+	case 2:
+		return s.Part2(input)
+	}
+	return "", Errorf(UsageError, "Expected puzzle part 1 or 2, got: %d", part)
+}
+
+// Solves `part` (1 or 2) of the puzzle in the file at `inputPath`,
+// which is opened with Open.
+func SolveFile(s Solver, part int, inputPath string) (Answer, error) {
+	inputFile, err := Open(inputPath)
+	if err != nil {
+		return "", err
+	}
+	defer inputFile.Close()
+	return Solve(s, part, inputFile)
+}
+
+var days = make(map[int]Day)
+
+// Makes `day` available to Lookup and Days.
+// Panics if a day with the same number is already registered.
+func Register(day Day) {
+	if _, ok := days[day.Number]; ok {
+		panic(fmt.Sprintf("Day %d registered twice!", day.Number))
+	}
+	days[day.Number] = day
+}
+
+func Lookup(number int) (Day, bool) {
+	day, ok := days[number]
+	return day, ok
+}
+
+// Returns all registered days, ordered by number.
+func Days() []Day {
+	numbers := slices.Sorted(maps.Keys(days))
+	result := make([]Day, len(numbers))
+	for i, n := range numbers {
+		result[i] = days[n]
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dinord/aoc23/parse"
+)
+
+type panickingSolver struct{}
+
+func (panickingSolver) Part1(input io.Reader) (Answer, error) {
+	panic("broken invariant")
+}
+
+func (panickingSolver) Part2(input io.Reader) (Answer, error) {
+	return "", errors.New("No answer")
+}
+
+func TestSolvePanics(t *testing.T) {
+	_, err := Solve(panickingSolver{}, 1, strings.NewReader(""))
+	if KindOf(err) != InternalError || !strings.Contains(err.Error(), "broken invariant") {
+		t.Errorf("Solve() = %v, want an internal error", err)
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	_, openErr := os.Open("does/not/exist")
+	tests := []struct {
+		err  error
+		want ErrorKind
+	}{
+		{errors.New("No answer"), InputError},
+		{fmt.Errorf("Day 1: %w", &parse.Error{Line: 1, Err: errors.New("Bad")}), InputError},
+		{Errorf(UsageError, "Bad flag"), UsageError},
+		{fmt.Errorf("Day 1: %w", openErr), IOError},
+		{&parse.ReadError{Err: io.ErrUnexpectedEOF}, IOError},
+		{fmt.Errorf("Day 1: %w", Errorf(InternalError, "Bug")), InternalError},
+	}
+	for _, tt := range tests {
+		if got := KindOf(tt.err); got != tt.want {
+			t.Errorf("KindOf(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}